@@ -0,0 +1,78 @@
+package blind
+
+import "time"
+
+// Level is a level of the blind structure.
+type Level struct {
+	SmallBlind int
+	BigBlind   int
+
+	// Ante is posted by every player, BigBlindAnte by the big blind for the whole table.
+	Ante         int
+	BigBlindAnte int
+
+	// Duration is how long the level lasts, zero means it is not limited by time.
+	Duration time.Duration
+
+	// Hands is how many hands the level lasts, zero means it is not limited by hand count.
+	Hands int
+}
+
+// Schedule is the list of levels, the blinds go up level by level.
+// The last level lasts until the end of the game.
+type Schedule []Level
+
+// Progress tracks the current level of a schedule.
+type Progress struct {
+	schedule Schedule
+
+	// level is the index of the current level in the schedule.
+	level int
+
+	// start is when the current level started.
+	start time.Time
+
+	// hands is how many hands have been played in the current level.
+	hands int
+}
+
+func NewProgress(schedule Schedule, start time.Time) *Progress {
+	return &Progress{
+		schedule: schedule,
+		start:    start,
+	}
+}
+
+// Advance records the hands played, and moves to the next level once the time or hand
+// count of the current level is up. It returns the current level afterwards.
+func (p *Progress) Advance(now time.Time, hands int) (int, Level) {
+	p.hands += hands
+	for p.level < len(p.schedule)-1 {
+		level := p.schedule[p.level]
+		timeUp := level.Duration > 0 && now.Sub(p.start) >= level.Duration
+		handsUp := level.Hands > 0 && p.hands >= level.Hands
+		if !timeUp && !handsUp {
+			break
+		}
+		if timeUp {
+			p.start = p.start.Add(level.Duration)
+		} else {
+			p.start = now
+		}
+		if handsUp {
+			p.hands -= level.Hands
+		} else {
+			p.hands = 0
+		}
+		p.level++
+	}
+	return p.Level()
+}
+
+// Level returns the index and the blinds of the current level.
+func (p *Progress) Level() (int, Level) {
+	if len(p.schedule) == 0 {
+		return 0, Level{}
+	}
+	return p.level, p.schedule[p.level]
+}
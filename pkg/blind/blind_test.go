@@ -0,0 +1,51 @@
+package blind
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressAdvance(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule := Schedule{
+		{SmallBlind: 10, BigBlind: 20, Duration: 10 * time.Minute},
+		{SmallBlind: 20, BigBlind: 40, Hands: 5},
+		{SmallBlind: 30, BigBlind: 60, Duration: 10 * time.Minute, Hands: 10},
+		{SmallBlind: 50, BigBlind: 100, Ante: 10},
+	}
+
+	testCases := []struct {
+		name    string
+		elapsed time.Duration
+		hands   int
+		want    int
+	}{
+		{"FirstLevel", 5 * time.Minute, 3, 0},
+		{"TimeUp", 10 * time.Minute, 2, 1},
+		{"NotEnoughHands", 11 * time.Minute, 4, 1},
+		{"HandsUp", 12 * time.Minute, 1, 2},
+		{"HandsUpBeforeTime", 13 * time.Minute, 10, 3},
+		{"LastLevel", 3 * time.Hour, 100, 3},
+	}
+
+	progress := NewProgress(schedule, start)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, level := progress.Advance(start.Add(tc.elapsed), tc.hands)
+			if got != tc.want {
+				t.Errorf("level: %d, want: %d", got, tc.want)
+			}
+			if level != schedule[tc.want] {
+				t.Errorf("level: %+v, want: %+v", level, schedule[tc.want])
+			}
+		})
+	}
+}
+
+func TestProgressEmptySchedule(t *testing.T) {
+	progress := NewProgress(nil, time.Now())
+	got, level := progress.Advance(time.Now(), 1)
+	if got != 0 || level != (Level{}) {
+		t.Errorf("level: %d %+v, want: 0 %+v", got, level, Level{})
+	}
+}
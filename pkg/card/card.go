@@ -19,6 +19,16 @@ func New(rank rank.Rank, suit suit.Suit) Card {
 	}
 }
 
+// NewJoker returns a joker, it has no suit.
+func NewJoker() Card {
+	return Card{rank: rank.Joker}
+}
+
+// IsJoker reports whether the card is a joker.
+func (c Card) IsJoker() bool {
+	return c.rank == rank.Joker
+}
+
 func (c Card) Rank() rank.Rank {
 	return c.rank
 }
@@ -28,5 +38,8 @@ func (c Card) Suit() suit.Suit {
 }
 
 func (c Card) String() string {
+	if c.IsJoker() {
+		return c.rank.String()
+	}
 	return fmt.Sprintf("%s of %s", c.rank.String(), c.suit.String())
 }
@@ -0,0 +1,97 @@
+package cfr
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// Exploitability returns what a best response wins against the strategy, on
+// average over the two players, zero at an equilibrium. The whole game tree is
+// walked, it is meant for small games.
+func Exploitability(root Game, strategy Strategy) float64 {
+	return (bestResponse(root, strategy, 0) + bestResponse(root, strategy, 1)) / 2
+}
+
+// reached is a state of an info set, with the probability chance and the other
+// player play to it.
+type reached struct {
+	game  Game
+	reach float64
+}
+
+// bestResponse returns the value to the player of the best response to the
+// strategy. The best actions are chosen from the deepest info sets up, the value
+// of an action depending on the choices after it.
+func bestResponse(root Game, strategy Strategy, player int) float64 {
+	infoSets := make(map[string][]reached)
+	depths := make(map[string]int)
+	var collect func(g Game, reach float64, depth int)
+	collect = func(g Game, reach float64, depth int) {
+		switch {
+		case g.IsTerminal():
+		case g.IsChance():
+			for _, chance := range g.Chances() {
+				collect(chance.Game, reach*chance.Probability, depth+1)
+			}
+		case g.Player() == player:
+			infoSet := g.InfoSet()
+			infoSets[infoSet] = append(infoSets[infoSet], reached{game: g, reach: reach})
+			depths[infoSet] = max(depths[infoSet], depth)
+			for a := range g.Actions() {
+				collect(g.Play(a), reach, depth+1)
+			}
+		default:
+			for a, p := range strategy.Probabilities(g.InfoSet(), len(g.Actions())) {
+				if p > 0 {
+					collect(g.Play(a), reach*p, depth+1)
+				}
+			}
+		}
+	}
+	collect(root, 1, 0)
+
+	choices := make(map[string]int, len(infoSets))
+	var value func(g Game) float64
+	value = func(g Game) float64 {
+		switch {
+		case g.IsTerminal():
+			return g.Payoff(player)
+		case g.IsChance():
+			v := 0.0
+			for _, chance := range g.Chances() {
+				v += chance.Probability * value(chance.Game)
+			}
+			return v
+		case g.Player() == player:
+			return value(g.Play(choices[g.InfoSet()]))
+		default:
+			v := 0.0
+			for a, p := range strategy.Probabilities(g.InfoSet(), len(g.Actions())) {
+				if p > 0 {
+					v += p * value(g.Play(a))
+				}
+			}
+			return v
+		}
+	}
+
+	keys := slices.SortedFunc(maps.Keys(infoSets), func(a, b string) int {
+		return cmp.Or(depths[b]-depths[a], cmp.Compare(a, b))
+	})
+	for _, infoSet := range keys {
+		states := infoSets[infoSet]
+		best, bestValue := 0, 0.0
+		for a := range states[0].game.Actions() {
+			v := 0.0
+			for _, state := range states {
+				v += state.reach * value(state.game.Play(a))
+			}
+			if a == 0 || v > bestValue {
+				best, bestValue = a, v
+			}
+		}
+		choices[infoSet] = best
+	}
+	return value(root)
+}
@@ -0,0 +1,36 @@
+package cfr
+
+// Game is a state of a two-player zero-sum game of imperfect information,
+// immutable: playing an action returns the next state. The players are 0 and 1.
+type Game interface {
+	// IsTerminal reports whether the game is over.
+	IsTerminal() bool
+
+	// Payoff returns what the player wins in a game over, the other player loses it.
+	Payoff(player int) float64
+
+	// IsChance reports whether the next move is dealt by chance.
+	IsChance() bool
+
+	// Chances returns the outcomes of a chance move, their probabilities sum to one.
+	Chances() []Chance
+
+	// Player returns the player to act.
+	Player() int
+
+	// InfoSet returns the key of what the player to act knows. States the player
+	// cannot tell apart share the key, and so the strategy.
+	InfoSet() string
+
+	// Actions returns the labels of the actions the player to act can take.
+	Actions() []string
+
+	// Play returns the state after the player to act takes the action at the index.
+	Play(action int) Game
+}
+
+// Chance is an outcome of a chance move.
+type Chance struct {
+	Game        Game
+	Probability float64
+}
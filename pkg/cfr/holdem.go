@@ -0,0 +1,269 @@
+package cfr
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/game"
+	"github.com/yshngg/holdem/pkg/hand"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/variant"
+)
+
+// Bucketing maps the hole cards and the board of a player to a bucket, the hands of
+// a bucket share a strategy.
+type Bucketing func(holeCards, board []card.Card) int
+
+// Abstraction coarsens heads-up hold'em into a game small enough to solve.
+type Abstraction struct {
+	// Bucket buckets the hands, HandBuckets if nil.
+	Bucket Bucketing
+
+	// Bets are the bets and raises considered, as fractions of the pot after
+	// calling. Folding, checking, calling and going all-in are always considered.
+	Bets []float64
+}
+
+// HandBuckets buckets the hands by what they make: before the flop the pairs by
+// rank and the other hands by their high card, suited or not, after it by the value
+// of the best hand.
+func HandBuckets(holeCards, board []card.Card) int {
+	if len(board) == 0 {
+		high, low := holeCards[0], holeCards[1]
+		if high.Rank() < low.Rank() {
+			high, low = low, high
+		}
+		if high.Rank() == low.Rank() {
+			return 2*int(rank.Joker) + int(high.Rank())
+		}
+		bucket := 2 * int(high.Rank())
+		if high.Suit() == low.Suit() {
+			bucket++
+		}
+		return bucket
+	}
+	score, _, err := hand.Holdem(holeCards, board)
+	if err != nil {
+		return 0
+	}
+	return int(score.Hand())
+}
+
+// Holdem is a heads-up hand of hold'em played in an abstraction: the players only
+// tell hands apart by bucket, and only bet the fractions of the pot of the
+// abstraction. Chance is sampled, every deal is a shuffled deck, solve it with
+// sampling.
+type Holdem struct {
+	config      game.Config
+	abstraction Abstraction
+	rand        *rand.Rand
+
+	dealt bool
+	state game.State
+
+	// history is the labels of the actions taken, streets split by a slash.
+	history string
+}
+
+var _ Game = Holdem{}
+
+// abstractAction is an action of the abstraction, played as the player action.
+type abstractAction struct {
+	label  string
+	action player.Action
+}
+
+// NewHoldem returns a heads-up hand in the abstraction, the cards to deal from
+// decks shuffled by the given source.
+func NewHoldem(config game.Config, abstraction Abstraction, r *rand.Rand) (Holdem, error) {
+	if config.Variant == nil {
+		config.Variant = variant.Holdem
+	}
+	if len(config.Stacks) != 2 {
+		return Holdem{}, fmt.Errorf("heads-up only, players: %d", len(config.Stacks))
+	}
+	// check the config on a deck in order
+	if _, err := game.New(config, config.Variant.Deck().List()); err != nil {
+		return Holdem{}, err
+	}
+	if abstraction.Bucket == nil {
+		abstraction.Bucket = HandBuckets
+	}
+	return Holdem{config: config, abstraction: abstraction, rand: r}, nil
+}
+
+// DealHoldem returns a heads-up hand in the abstraction dealt from the deck, in
+// order, for a bot to play the strategy.
+func DealHoldem(config game.Config, deck []card.Card, abstraction Abstraction) (Holdem, error) {
+	if len(config.Stacks) != 2 {
+		return Holdem{}, fmt.Errorf("heads-up only, players: %d", len(config.Stacks))
+	}
+	state, err := game.New(config, deck)
+	if err != nil {
+		return Holdem{}, err
+	}
+	if abstraction.Bucket == nil {
+		abstraction.Bucket = HandBuckets
+	}
+	return Holdem{config: config, abstraction: abstraction, dealt: true, state: state}, nil
+}
+
+// State returns the hand as played.
+func (h Holdem) State() game.State {
+	return h.state
+}
+
+func (h Holdem) IsTerminal() bool {
+	return h.dealt && h.state.IsTerminal()
+}
+
+func (h Holdem) Payoff(player int) float64 {
+	payoffs, err := h.state.Payoffs()
+	if err != nil {
+		panic(err)
+	}
+	return float64(payoffs[player])
+}
+
+func (h Holdem) IsChance() bool {
+	return !h.dealt
+}
+
+// Chances deals a single shuffled deck.
+func (h Holdem) Chances() []Chance {
+	cards := h.config.Variant.Deck().List()
+	h.rand.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+	})
+	dealt, err := DealHoldem(h.config, cards, h.abstraction)
+	if err != nil {
+		panic(err)
+	}
+	return []Chance{{Game: dealt, Probability: 1}}
+}
+
+func (h Holdem) Player() int {
+	return h.state.ToAct()
+}
+
+func (h Holdem) InfoSet() string {
+	seat := h.state.ToAct()
+	return strconv.Itoa(h.abstraction.Bucket(h.state.HoleCards(seat), h.state.Board())) + ":" + h.history
+}
+
+func (h Holdem) Actions() []string {
+	actions := h.actions()
+	labels := make([]string, len(actions))
+	for i, action := range actions {
+		labels[i] = action.label
+	}
+	return labels
+}
+
+func (h Holdem) Play(action int) Game {
+	abstract := h.actions()[action]
+	state, err := h.state.Apply(abstract.action)
+	if err != nil {
+		panic(fmt.Errorf("apply abstract action %s, err: %w", abstract.label, err))
+	}
+	return h.next(state, abstract.label)
+}
+
+// Action returns the action to take at the table for the action at the index.
+func (h Holdem) Action(action int) player.Action {
+	return h.actions()[action].action
+}
+
+// Observe returns the hand after the player to act takes an action at the table,
+// seen in the abstraction as the action of the same type closest in chips. Bets
+// and raises are seen as going all-in if that is closer.
+func (h Holdem) Observe(action player.Action) (Holdem, error) {
+	closest, distance := -1, math.MaxInt
+	for i, abstract := range h.actions() {
+		if abstract.action.Type != action.Type && !(bets(abstract.action.Type) && bets(action.Type)) {
+			continue
+		}
+		chips := action.Chips
+		if action.Type == player.ActionAllIn {
+			chips = h.state.Stacks()[h.state.ToAct()]
+		}
+		if d := abs(abstract.action.Chips - chips); d < distance {
+			closest, distance = i, d
+		}
+	}
+	if closest < 0 {
+		return h, fmt.Errorf("action not in the abstraction: %v", action.Type)
+	}
+	state, err := h.state.Apply(action)
+	if err != nil {
+		return h, err
+	}
+	return h.next(state, h.actions()[closest].label), nil
+}
+
+// bets reports whether the action puts in chips by choice: a bet, a raise, or going
+// all-in.
+func bets(t player.ActionType) bool {
+	return t == player.ActionBet || t == player.ActionRaise || t == player.ActionAllIn
+}
+
+func abs(x int) int {
+	return max(x, -x)
+}
+
+func (h Holdem) next(state game.State, label string) Holdem {
+	h.history += label
+	if !state.IsTerminal() && state.Street() != h.state.Street() {
+		h.history += "/"
+	}
+	h.state = state
+	return h
+}
+
+// actions returns the legal actions in the abstraction, the bets and raises sized
+// as fractions of the pot after calling, within the limit.
+func (h Holdem) actions() []abstractAction {
+	seat := h.state.ToAct()
+	street, stack := h.state.Bets(), h.state.Stacks()[seat]
+	toCall := max(street[0], street[1]) - street[seat]
+	pot := h.state.Pot() + toCall
+
+	actions := make([]abstractAction, 0, 3+len(h.abstraction.Bets))
+	for _, legal := range h.state.LegalActions() {
+		switch legal.Type {
+		case player.ActionFold:
+			actions = append(actions, abstractAction{label: "f", action: legal})
+		case player.ActionCheck:
+			actions = append(actions, abstractAction{label: "k", action: legal})
+		case player.ActionCall:
+			actions = append(actions, abstractAction{label: "c", action: legal})
+		case player.ActionAllIn:
+			actions = append(actions, abstractAction{label: "a", action: legal})
+		case player.ActionBet, player.ActionRaise:
+			most := stack
+			if legal.Max > 0 {
+				most = min(most, legal.Max)
+			}
+			for _, fraction := range h.abstraction.Bets {
+				chips := toCall + int(math.Round(fraction*float64(pot)))
+				chips = min(max(chips, legal.Chips), most)
+				// going all-in is considered on its own
+				if chips >= stack || slices.ContainsFunc(actions, func(a abstractAction) bool {
+					return a.action.Type == legal.Type && a.action.Chips == chips
+				}) {
+					continue
+				}
+				actions = append(actions, abstractAction{
+					label:  "b" + strconv.FormatFloat(fraction, 'g', -1, 64),
+					action: player.Action{Type: legal.Type, Chips: chips},
+				})
+			}
+		}
+	}
+	return actions
+}
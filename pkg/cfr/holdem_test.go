@@ -0,0 +1,78 @@
+package cfr
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/deck"
+	"github.com/yshngg/holdem/pkg/game"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+var (
+	holdemConfig = game.Config{SmallBlind: 1, BigBlind: 2, Stacks: []int{20, 20}}
+	abstraction  = Abstraction{Bets: []float64{0.5, 1}}
+)
+
+func TestHandBuckets(t *testing.T) {
+	aces := []card.Card{card.New(rank.Ace, suit.Clubs), card.New(rank.Ace, suit.Hearts)}
+	suited := []card.Card{card.New(rank.King, suit.Clubs), card.New(rank.Ace, suit.Clubs)}
+	offsuit := []card.Card{card.New(rank.Ace, suit.Clubs), card.New(rank.King, suit.Hearts)}
+	if HandBuckets(suited, nil) == HandBuckets(offsuit, nil) || HandBuckets(aces, nil) == HandBuckets(suited, nil) {
+		t.Errorf("HandBuckets() = %d, %d, %d, want the pairs and the suited hands apart",
+			HandBuckets(aces, nil), HandBuckets(suited, nil), HandBuckets(offsuit, nil))
+	}
+	board := []card.Card{card.New(rank.Ace, suit.Spades), card.New(rank.Two, suit.Hearts), card.New(rank.Seven, suit.Diamonds)}
+	if HandBuckets(aces, board) == HandBuckets(offsuit, board) {
+		t.Errorf("HandBuckets() = %d on the flop for a set and a pair, want them apart", HandBuckets(aces, board))
+	}
+}
+
+func TestHoldem(t *testing.T) {
+	root, err := NewHoldem(holdemConfig, abstraction, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("NewHoldem().err = %v", err)
+	}
+	s := New(root, WithSampling(rand.New(rand.NewSource(2))))
+	s.Train(200)
+	strategy := s.Strategy()
+	if len(strategy) == 0 {
+		t.Fatalf("Strategy() is empty")
+	}
+
+	// a bot deals the hand at the table and plays the strategy
+	h, err := DealHoldem(holdemConfig, deck.New().List(), abstraction)
+	if err != nil {
+		t.Fatalf("DealHoldem().err = %v", err)
+	}
+	action := h.Action(strategy.Act(h, rand.New(rand.NewSource(3))))
+	if !slices.ContainsFunc(h.State().LegalActions(), func(legal player.Action) bool {
+		return legal.Type == action.Type
+	}) {
+		t.Errorf("Action() = %v, want a legal action", action)
+	}
+
+	// the pot after calling is 4, the abstraction raises 2 or 4 more
+	if got := h.Actions(); !slices.Equal(got, []string{"f", "c", "b0.5", "b1", "a"}) {
+		t.Errorf("Actions() = %v", got)
+	}
+	observed, err := h.Observe(player.Action{Type: player.ActionRaise, Chips: 6})
+	if err != nil {
+		t.Fatalf("Observe().err = %v", err)
+	}
+	if !strings.HasSuffix(observed.InfoSet(), ":b1") {
+		t.Errorf("InfoSet() = %s, want the raise seen as a pot-sized raise", observed.InfoSet())
+	}
+	// the blinds and the six chips put in
+	if observed.State().Pot() != 9 {
+		t.Errorf("Pot() = %d, want the raise played as it was", observed.State().Pot())
+	}
+	if _, err := NewHoldem(game.Config{SmallBlind: 1, BigBlind: 2, Stacks: []int{20, 20, 20}}, abstraction, nil); err == nil {
+		t.Errorf("NewHoldem() with three players, err = nil")
+	}
+}
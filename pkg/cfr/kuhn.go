@@ -0,0 +1,89 @@
+package cfr
+
+import "strings"
+
+// kuhnCards are the jack, the queen and the king.
+const kuhnCards = "JQK"
+
+// Kuhn is Kuhn poker: a deck of three cards, one dealt to each player who antes
+// one chip, and a single round where a bet is of one chip. Its value to the first
+// player is -1/18.
+type Kuhn struct {
+	dealt   bool
+	cards   [2]int
+	history string
+}
+
+var _ Game = Kuhn{}
+
+// NewKuhn returns a game of Kuhn poker, the cards to deal.
+func NewKuhn() Kuhn {
+	return Kuhn{}
+}
+
+func (k Kuhn) IsTerminal() bool {
+	switch k.history {
+	case "pp", "bp", "bb", "pbp", "pbb":
+		return true
+	}
+	return false
+}
+
+func (k Kuhn) Payoff(player int) float64 {
+	sign := 1.0
+	if player == 1 {
+		sign = -1
+	}
+	// the player who passes after a bet folds
+	switch k.history {
+	case "bp":
+		return sign
+	case "pbp":
+		return -sign
+	}
+	stake := 1.0
+	if strings.HasSuffix(k.history, "bb") {
+		stake = 2
+	}
+	if k.cards[0] < k.cards[1] {
+		stake = -stake
+	}
+	return sign * stake
+}
+
+func (k Kuhn) IsChance() bool {
+	return !k.dealt
+}
+
+func (k Kuhn) Chances() []Chance {
+	chances := make([]Chance, 0, 6)
+	for first := range len(kuhnCards) {
+		for second := range len(kuhnCards) {
+			if first != second {
+				chances = append(chances, Chance{
+					Game:        Kuhn{dealt: true, cards: [2]int{first, second}},
+					Probability: 1.0 / 6,
+				})
+			}
+		}
+	}
+	return chances
+}
+
+func (k Kuhn) Player() int {
+	return len(k.history) % 2
+}
+
+func (k Kuhn) InfoSet() string {
+	return string(kuhnCards[k.cards[k.Player()]]) + k.history
+}
+
+// Actions are to pass, check or fold, and to bet or call.
+func (k Kuhn) Actions() []string {
+	return []string{"p", "b"}
+}
+
+func (k Kuhn) Play(action int) Game {
+	k.history += k.Actions()[action]
+	return k
+}
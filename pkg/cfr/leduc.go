@@ -0,0 +1,162 @@
+package cfr
+
+import "strings"
+
+const (
+	// leducCards are the ranks of the deck, two cards of each.
+	leducCards = "JQK"
+
+	// leducRaises caps a betting round at two raises.
+	leducRaises = 2
+)
+
+// leducBets are the raises of the two betting rounds.
+var leducBets = [2]float64{2, 4}
+
+// Leduc is Leduc hold'em: a deck of two jacks, two queens and two kings, one card
+// dealt to each player who antes one chip, and a betting round before and after a
+// board card is dealt. A pair with the board wins, then the higher card. Its value
+// to the first player is about -0.0856.
+type Leduc struct {
+	dealt bool
+	cards [2]int
+
+	// board is the board card, negative until dealt.
+	board int
+
+	// rounds are the actions of the betting rounds.
+	rounds [2]string
+
+	folded    int
+	committed [2]float64
+}
+
+var _ Game = Leduc{}
+
+// NewLeduc returns a game of Leduc hold'em, the cards to deal.
+func NewLeduc() Leduc {
+	return Leduc{board: -1, folded: -1, committed: [2]float64{1, 1}}
+}
+
+// round returns the betting round being played.
+func (l Leduc) round() int {
+	if l.board < 0 {
+		return 0
+	}
+	return 1
+}
+
+// over reports whether the betting round is over: both checked, or the last raise
+// was called.
+func (l Leduc) over(round int) bool {
+	actions := l.rounds[round]
+	return actions == "cc" || len(actions) >= 2 && strings.HasSuffix(actions, "c") && strings.Contains(actions, "r")
+}
+
+func (l Leduc) IsTerminal() bool {
+	return l.folded >= 0 || l.over(1)
+}
+
+func (l Leduc) Payoff(player int) float64 {
+	other := 1 - player
+	switch {
+	case l.folded == player:
+		return -l.committed[player]
+	case l.folded == other:
+		return l.committed[other]
+	}
+	strength := func(p int) int {
+		if l.cards[p] == l.board {
+			return len(leducCards) + l.cards[p]
+		}
+		return l.cards[p]
+	}
+	switch {
+	case strength(player) > strength(other):
+		return l.committed[other]
+	case strength(player) < strength(other):
+		return -l.committed[player]
+	}
+	return 0
+}
+
+func (l Leduc) IsChance() bool {
+	return !l.dealt || l.board < 0 && l.over(0)
+}
+
+func (l Leduc) Chances() []Chance {
+	if !l.dealt {
+		chances := make([]Chance, 0, len(leducCards)*len(leducCards))
+		for first := range len(leducCards) {
+			for second := range len(leducCards) {
+				// two of the five cards left are of another rank, one of the same
+				probability := 2.0 / 6 * 2 / 5
+				if first == second {
+					probability = 2.0 / 6 * 1 / 5
+				}
+				next := l
+				next.dealt, next.cards = true, [2]int{first, second}
+				chances = append(chances, Chance{Game: next, Probability: probability})
+			}
+		}
+		return chances
+	}
+	chances := make([]Chance, 0, len(leducCards))
+	for board := range len(leducCards) {
+		left := 2
+		for _, c := range l.cards {
+			if c == board {
+				left--
+			}
+		}
+		if left == 0 {
+			continue
+		}
+		next := l
+		next.board = board
+		chances = append(chances, Chance{Game: next, Probability: float64(left) / 4})
+	}
+	return chances
+}
+
+func (l Leduc) Player() int {
+	return len(l.rounds[l.round()]) % 2
+}
+
+func (l Leduc) InfoSet() string {
+	board := ""
+	if l.board >= 0 {
+		board = string(leducCards[l.board])
+	}
+	return string(leducCards[l.cards[l.Player()]]) + board + ":" + l.rounds[0] + "/" + l.rounds[1]
+}
+
+// Actions are to fold facing a raise, to check or call, and to raise up to the cap.
+func (l Leduc) Actions() []string {
+	actions := l.rounds[l.round()]
+	raises := strings.Count(actions, "r")
+	facing := strings.HasSuffix(actions, "r")
+	switch {
+	case facing && raises < leducRaises:
+		return []string{"f", "c", "r"}
+	case facing:
+		return []string{"f", "c"}
+	default:
+		return []string{"c", "r"}
+	}
+}
+
+func (l Leduc) Play(action int) Game {
+	round, player := l.round(), l.Player()
+	label := l.Actions()[action]
+	l.rounds[round] += label
+	switch label {
+	case "f":
+		l.folded = player
+	case "c":
+		l.committed[player] = l.committed[1-player]
+	case "r":
+		l.committed[player] = l.committed[1-player] + leducBets[round]
+	}
+	return l
+}
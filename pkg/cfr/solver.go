@@ -0,0 +1,261 @@
+package cfr
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// node holds the regrets and the sum of the strategies played at an info set. The
+// regrets of an iteration are pending until the end of the walk, the strategy
+// played at an info set is the same however it is reached.
+type node struct {
+	regrets, pending, strategySum []float64
+}
+
+func newNode(actions int) *node {
+	return &node{
+		regrets:     make([]float64, actions),
+		pending:     make([]float64, actions),
+		strategySum: make([]float64, actions),
+	}
+}
+
+// strategy returns the current strategy by regret matching: in proportion to the
+// positive regrets, uniform if none is positive.
+func (n *node) strategy() []float64 {
+	strategy := make([]float64, len(n.regrets))
+	sum := 0.0
+	for a, regret := range n.regrets {
+		strategy[a] = max(regret, 0)
+		sum += strategy[a]
+	}
+	for a := range strategy {
+		if sum > 0 {
+			strategy[a] /= sum
+		} else {
+			strategy[a] = 1 / float64(len(strategy))
+		}
+	}
+	return strategy
+}
+
+// average returns the average strategy, which converges to an equilibrium.
+func (n *node) average() []float64 {
+	average := slices.Clone(n.strategySum)
+	sum := 0.0
+	for _, p := range average {
+		sum += p
+	}
+	for a := range average {
+		if sum > 0 {
+			average[a] /= sum
+		} else {
+			average[a] = 1 / float64(len(average))
+		}
+	}
+	return average
+}
+
+// Solver finds an approximate equilibrium of a game by counterfactual regret
+// minimization: every iteration walks the game tree for each player in turn,
+// accumulating the regret of not having played every action at every info set.
+type Solver struct {
+	root Game
+
+	// plus floors the regrets at zero and weighs the average strategy by the
+	// iteration, CFR+.
+	plus bool
+
+	// rand samples the chance outcomes and the actions of the other player, Monte
+	// Carlo CFR with external sampling. The whole tree is walked if nil.
+	rand *rand.Rand
+
+	nodes     map[string]*node
+	iteration int
+
+	// updated are the nodes with pending regrets.
+	updated []*node
+}
+
+type Option func(*Solver)
+
+// WithPlus runs CFR+, which converges faster on most games.
+func WithPlus() Option {
+	return func(s *Solver) {
+		s.plus = true
+	}
+}
+
+// WithSampling runs Monte Carlo CFR with external sampling: a single chance outcome
+// and a single action of the other player are walked, drawn from the given source.
+// Iterations are much cheaper, and more of them are needed.
+func WithSampling(r *rand.Rand) Option {
+	return func(s *Solver) {
+		s.rand = r
+	}
+}
+
+// New returns a solver of the game from the given state.
+func New(root Game, opts ...Option) *Solver {
+	s := &Solver{
+		root:  root,
+		nodes: make(map[string]*node),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Train runs the given number of iterations.
+func (s *Solver) Train(iterations int) {
+	for range iterations {
+		s.iteration++
+		for player := range 2 {
+			s.walk(s.root, player, 1, 1)
+			s.update()
+		}
+	}
+}
+
+// Iterations returns the number of iterations run.
+func (s *Solver) Iterations() int {
+	return s.iteration
+}
+
+// Strategy returns the average strategy of every info set visited.
+func (s *Solver) Strategy() Strategy {
+	strategy := make(Strategy, len(s.nodes))
+	for infoSet, n := range s.nodes {
+		strategy[infoSet] = n.average()
+	}
+	return strategy
+}
+
+// update adds the pending regrets, floored at zero in CFR+.
+func (s *Solver) update() {
+	for _, n := range s.updated {
+		for a, regret := range n.pending {
+			n.regrets[a] += regret
+			if s.plus {
+				n.regrets[a] = max(n.regrets[a], 0)
+			}
+		}
+		clear(n.pending)
+	}
+	s.updated = s.updated[:0]
+}
+
+func (s *Solver) node(infoSet string, actions int) *node {
+	n, ok := s.nodes[infoSet]
+	if !ok {
+		n = newNode(actions)
+		s.nodes[infoSet] = n
+	}
+	return n
+}
+
+// walk returns the value of the state to the player updating the regrets, reach
+// being the probability the player plays to the state, and others the probability
+// chance and the other player do.
+func (s *Solver) walk(g Game, player int, reach, others float64) float64 {
+	if g.IsTerminal() {
+		return g.Payoff(player)
+	}
+	if g.IsChance() {
+		chances := g.Chances()
+		if s.rand != nil {
+			return s.walk(chances[sample(s.rand, probabilities(chances))].Game, player, reach, others)
+		}
+		value := 0.0
+		for _, chance := range chances {
+			value += chance.Probability * s.walk(chance.Game, player, reach, others*chance.Probability)
+		}
+		return value
+	}
+
+	n := s.node(g.InfoSet(), len(g.Actions()))
+	strategy := n.strategy()
+	if g.Player() != player {
+		if s.rand != nil {
+			// the average strategy is accumulated where it is sampled from
+			for a, p := range strategy {
+				n.strategySum[a] += s.weight() * p
+			}
+			return s.walk(g.Play(sample(s.rand, strategy)), player, reach, others)
+		}
+		value := 0.0
+		for a, p := range strategy {
+			if p > 0 {
+				value += p * s.walk(g.Play(a), player, reach, others*p)
+			}
+		}
+		return value
+	}
+
+	values := make([]float64, len(strategy))
+	value := 0.0
+	for a, p := range strategy {
+		values[a] = s.walk(g.Play(a), player, reach*p, others)
+		value += p * values[a]
+	}
+	if !slices.ContainsFunc(n.pending, func(regret float64) bool { return regret != 0 }) {
+		s.updated = append(s.updated, n)
+	}
+	for a := range values {
+		n.pending[a] += others * (values[a] - value)
+		if s.rand == nil {
+			n.strategySum[a] += s.weight() * reach * strategy[a]
+		}
+	}
+	return value
+}
+
+// weight returns the weight of the iteration in the average strategy.
+func (s *Solver) weight() float64 {
+	if s.plus {
+		return float64(s.iteration)
+	}
+	return 1
+}
+
+// Value returns the value of the game to player 0 when both players play the
+// strategy, the chance outcomes all walked.
+func Value(g Game, strategy Strategy) float64 {
+	if g.IsTerminal() {
+		return g.Payoff(0)
+	}
+	value := 0.0
+	if g.IsChance() {
+		for _, chance := range g.Chances() {
+			value += chance.Probability * Value(chance.Game, strategy)
+		}
+		return value
+	}
+	for a, p := range strategy.Probabilities(g.InfoSet(), len(g.Actions())) {
+		if p > 0 {
+			value += p * Value(g.Play(a), strategy)
+		}
+	}
+	return value
+}
+
+func probabilities(chances []Chance) []float64 {
+	ps := make([]float64, len(chances))
+	for i, chance := range chances {
+		ps[i] = chance.Probability
+	}
+	return ps
+}
+
+// sample returns an index drawn from the distribution.
+func sample(r *rand.Rand, distribution []float64) int {
+	x := r.Float64()
+	for i, p := range distribution {
+		if x < p {
+			return i
+		}
+		x -= p
+	}
+	return len(distribution) - 1
+}
@@ -0,0 +1,73 @@
+package cfr
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestKuhn(t *testing.T) {
+	testCases := []struct {
+		name       string
+		opts       []Option
+		iterations int
+	}{
+		{name: "CFR", iterations: 1000},
+		{name: "CFRPlus", opts: []Option{WithPlus()}, iterations: 1000},
+		{name: "MonteCarlo", opts: []Option{WithSampling(rand.New(rand.NewSource(1)))}, iterations: 50000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := New(NewKuhn(), tc.opts...)
+			s.Train(tc.iterations)
+			strategy := s.Strategy()
+			if len(strategy) != 12 {
+				t.Errorf("len(Strategy()) = %d, want 12 info sets", len(strategy))
+			}
+			if value := Value(NewKuhn(), strategy); math.Abs(value+1.0/18) > 0.005 {
+				t.Errorf("Value() = %f, want %f", value, -1.0/18)
+			}
+			if exploitability := Exploitability(NewKuhn(), strategy); exploitability > 0.01 {
+				t.Errorf("Exploitability() = %f, want under 0.01", exploitability)
+			}
+		})
+	}
+}
+
+func TestLeduc(t *testing.T) {
+	if exploitability := Exploitability(NewLeduc(), Strategy{}); math.Abs(exploitability-2.3736) > 0.001 {
+		t.Errorf("Exploitability() of the uniform strategy = %f, want 2.3736", exploitability)
+	}
+	s := New(NewLeduc(), WithPlus())
+	s.Train(500)
+	strategy := s.Strategy()
+	if value := Value(NewLeduc(), strategy); math.Abs(value+0.0856) > 0.005 {
+		t.Errorf("Value() = %f, want -0.0856", value)
+	}
+	if exploitability := Exploitability(NewLeduc(), strategy); exploitability > 0.01 {
+		t.Errorf("Exploitability() = %f, want under 0.01", exploitability)
+	}
+}
+
+func TestStrategy(t *testing.T) {
+	s := New(NewKuhn(), WithPlus())
+	s.Train(100)
+	strategy := s.Strategy()
+
+	var buf bytes.Buffer
+	if err := strategy.Encode(&buf); err != nil {
+		t.Fatalf("Encode().err = %v", err)
+	}
+	decoded, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode().err = %v", err)
+	}
+	if Value(NewKuhn(), decoded) != Value(NewKuhn(), strategy) {
+		t.Errorf("Value() of the decoded strategy = %f, want %f", Value(NewKuhn(), decoded), Value(NewKuhn(), strategy))
+	}
+	if got := decoded.Probabilities("unknown", 4); got[0] != 0.25 || got[3] != 0.25 {
+		t.Errorf("Probabilities() of an unknown info set = %v, want uniform", got)
+	}
+}
@@ -0,0 +1,47 @@
+package cfr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// Strategy is the probabilities of the actions at every info set, in the order of
+// the actions of the game. It is exported as JSON for a bot to play.
+type Strategy map[string][]float64
+
+// Probabilities returns the probabilities of the actions at the info set, uniform
+// if the info set was never visited.
+func (s Strategy) Probabilities(infoSet string, actions int) []float64 {
+	if ps, ok := s[infoSet]; ok && len(ps) == actions {
+		return ps
+	}
+	ps := make([]float64, actions)
+	for a := range ps {
+		ps[a] = 1 / float64(actions)
+	}
+	return ps
+}
+
+// Act returns the index of the action drawn from the strategy for the player to act.
+func (s Strategy) Act(g Game, r *rand.Rand) int {
+	return sample(r, s.Probabilities(g.InfoSet(), len(g.Actions())))
+}
+
+// Encode writes the strategy as JSON.
+func (s Strategy) Encode(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		return fmt.Errorf("encode strategy, err: %w", err)
+	}
+	return nil
+}
+
+// Decode reads a strategy written by Encode.
+func Decode(r io.Reader) (Strategy, error) {
+	s := make(Strategy)
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("decode strategy, err: %w", err)
+	}
+	return s, nil
+}
@@ -2,6 +2,7 @@ package dealer
 
 import (
 	"math/rand"
+	"slices"
 
 	"github.com/yshngg/holdem/pkg/card"
 	"github.com/yshngg/holdem/pkg/deck"
@@ -44,6 +45,11 @@ func (d *Dealer) Reset() {
 	d.deck = deck.New()
 }
 
+// SetDeck replaces the deck to deal from, such as a fresh one for every hand.
+func (d *Dealer) SetDeck(_deck *deck.Deck) {
+	d.deck = _deck
+}
+
 func (d *Dealer) deal() *card.Card {
 	return d.deck.Pop()
 }
@@ -52,18 +58,32 @@ func (d *Dealer) Shuffle() {
 	rand.Shuffle(d.deck.Len(), d.deck.Swap)
 }
 
+// DealHoleCards deals two hole cards to every player, as in hold'em.
 func (d *Dealer) DealHoleCards(playerCont int) [][2]*card.Card {
 	holeCards := make([][2]*card.Card, playerCont)
-	for i := range 2 {
-		for j := range playerCont {
-			card := d.deal()
-			if card == nil {
+	for i, cards := range d.DealCards(playerCont, 2) {
+		holeCards[i] = [2]*card.Card(cards)
+	}
+	return holeCards
+}
+
+// DealCards deals the given number of cards to every player, one card at a time
+// round the table.
+func (d *Dealer) DealCards(playerCount, count int) [][]*card.Card {
+	cards := make([][]*card.Card, playerCount)
+	for i := range count {
+		for j := range playerCount {
+			c := d.deal()
+			if c == nil {
 				panic("deck is empty")
 			}
-			holeCards[i][j] = card
+			if i == 0 {
+				cards[j] = make([]*card.Card, 0, count)
+			}
+			cards[j] = append(cards[j], c)
 		}
 	}
-	return holeCards
+	return cards
 }
 
 func (d *Dealer) DealFlopCards() [3]*card.Card {
@@ -102,6 +122,11 @@ func (d *Dealer) BurnCard() *card.Card {
 	return card
 }
 
+// Remaining returns the cards left in the deck, in the order they are dealt.
+func (d *Dealer) Remaining() []card.Card {
+	return slices.Clone(d.deck.List())
+}
+
 func (d *Dealer) Deal() *card.Card {
 	return d.deal()
 }
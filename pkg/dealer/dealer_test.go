@@ -13,3 +13,23 @@ func TestDeal(t *testing.T) {
 	_card := dealer.Deal()
 	t.Logf("Card: %v", _card)
 }
+
+func TestDealCards(t *testing.T) {
+	dealer := New()
+	cards := dealer.DealCards(3, 4)
+	if len(cards) != 3 {
+		t.Fatalf("players: %d, want: %d", len(cards), 3)
+	}
+	for i := range cards {
+		if len(cards[i]) != 4 {
+			t.Errorf("cards of player %d: %d, want: %d", i, len(cards[i]), 4)
+		}
+	}
+	// one card at a time round the table
+	if *cards[1][0] != deck.New().List()[1] {
+		t.Errorf("first card of player 1: %v, want: %v", *cards[1][0], deck.New().List()[1])
+	}
+	if got := len(dealer.Remaining()); got != 52-12 {
+		t.Errorf("remaining: %d, want: %d", got, 52-12)
+	}
+}
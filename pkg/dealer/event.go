@@ -16,6 +16,9 @@ const (
 
 	EventShuffle       EventAction = "Shuffle"
 	EventDealHoleCards EventAction = "DealHoleCards"
+	EventDealUpCards   EventAction = "DealUpCards"
+	EventDrawCards     EventAction = "DrawCards"
+	EventMuckCards     EventAction = "MuckCards"
 	EventDealFlopCards EventAction = "DealFlopCards"
 	EventDealTurnCard  EventAction = "DealTurnCard"
 	EventDealRiverCard EventAction = "DealRiverCard"
@@ -37,6 +40,9 @@ func ToAll() string {
 type EventObject struct {
 	Cards []*card.Card
 	To    string
+
+	// ID is the player the cards are dealt face up to, as in stud.
+	ID string
 }
 
 type Event struct {
@@ -59,6 +65,20 @@ func NewEvent(action EventAction, to string, cards ...*card.Card) watch.Event {
 	return e
 }
 
+// NewUpCardsEvent instances a new dealer Event of the cards dealt face up to the
+// player, seen by all.
+func NewUpCardsEvent(id string, cards ...*card.Card) watch.Event {
+	return Event{
+		action: EventDealUpCards,
+		object: EventObject{
+			To:    ToAll(),
+			Cards: cards,
+			ID:    id,
+		},
+		eventTime: time.Now(),
+	}
+}
+
 func (e Event) Kind() string {
 	return EventKind
 }
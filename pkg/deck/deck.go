@@ -1,6 +1,8 @@
 package deck
 
 import (
+	"slices"
+
 	"github.com/yshngg/holdem/pkg/card"
 	"github.com/yshngg/holdem/pkg/rank"
 	"github.com/yshngg/holdem/pkg/suit"
@@ -8,23 +10,71 @@ import (
 
 type Deck struct {
 	cards []card.Card
+
+	// copies is the number of decks shuffled together, jokers the number of jokers
+	// added to them.
+	copies, jokers int
 }
 
-func New() *Deck {
-	d := &Deck{}
-	for s := suit.Clubs; s <= suit.Diamonds; s++ {
-		c := card.New(rank.Ace, s)
-		d.cards = append(d.cards, c)
+type Option func(*Deck)
+
+// WithCopies shuffles several decks together, such as 2 for a double deck. The
+// same card might then be dealt more than once.
+func WithCopies(copies int) Option {
+	return func(d *Deck) {
+		d.copies = copies
 	}
-	for r := rank.Two; r <= rank.King; r++ {
+}
+
+// WithJokers adds the given number of jokers to the deck.
+func WithJokers(jokers int) Option {
+	return func(d *Deck) {
+		d.jokers = jokers
+	}
+}
+
+// New returns a deck of 52 cards, aces first, then from the twos up, every rank in
+// the order of the suits.
+func New(opts ...Option) *Deck {
+	return build(rank.Two, opts...)
+}
+
+// NewShort returns a short deck of 36 cards, the twos to the fives removed,
+// in the same order as New.
+func NewShort(opts ...Option) *Deck {
+	return build(rank.Six, opts...)
+}
+
+// build returns the decks of the ranks from the lowest one, with the jokers last.
+func build(lowest rank.Rank, opts ...Option) *Deck {
+	d := &Deck{copies: 1}
+	for _, opt := range opts {
+		opt(d)
+	}
+	for range d.copies {
 		for s := suit.Clubs; s <= suit.Diamonds; s++ {
-			c := card.New(r, s)
+			c := card.New(rank.Ace, s)
 			d.cards = append(d.cards, c)
 		}
+		for r := lowest; r <= rank.King; r++ {
+			for s := suit.Clubs; s <= suit.Diamonds; s++ {
+				c := card.New(r, s)
+				d.cards = append(d.cards, c)
+			}
+		}
+	}
+	for range d.jokers {
+		d.cards = append(d.cards, card.NewJoker())
 	}
 	return d
 }
 
+// Of returns a deck of the given cards, in order, such as the discards reshuffled
+// in draw games.
+func Of(cards ...card.Card) *Deck {
+	return &Deck{cards: slices.Clone(cards)}
+}
+
 func (d Deck) Len() int {
 	return len(d.cards)
 }
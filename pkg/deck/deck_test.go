@@ -82,3 +82,66 @@ func TestSwap(t *testing.T) {
 		t.Errorf("swap cards: %s, want %s", _deck.cards[_deck.Len()-1], first)
 	}
 }
+
+func TestNewShort(t *testing.T) {
+	_deck := NewShort()
+	if _deck.Len() != 36 {
+		t.Errorf("deck length: %d, want 36", _deck.Len())
+	}
+	for _, c := range _deck.List() {
+		if c.Rank() < rank.Six {
+			t.Errorf("card: %v, want six or higher", c)
+		}
+	}
+}
+
+func TestOf(t *testing.T) {
+	cards := New().List()[:3]
+	_deck := Of(cards...)
+	if _deck.Len() != 3 {
+		t.Errorf("deck length: %d, want 3", _deck.Len())
+	}
+	for _, c := range cards {
+		if got := _deck.Pop(); *got != c {
+			t.Errorf("pop: %v, want %v", got, c)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		opts       []Option
+		length     int
+		jokers     int
+		aceOfClubs int
+	}{
+		{name: "Default", length: 52, aceOfClubs: 1},
+		{name: "DoubleDeck", opts: []Option{WithCopies(2)}, length: 104, aceOfClubs: 2},
+		{name: "Jokers", opts: []Option{WithJokers(2)}, length: 54, jokers: 2, aceOfClubs: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_deck := New(tc.opts...)
+			if _deck.Len() != tc.length {
+				t.Errorf("deck length: %d, want %d", _deck.Len(), tc.length)
+			}
+			jokers, aceOfClubs := 0, 0
+			for _, c := range _deck.List() {
+				if c.IsJoker() {
+					jokers++
+				}
+				if c.Rank() == rank.Ace && c.Suit() == suit.Clubs {
+					aceOfClubs++
+				}
+			}
+			if jokers != tc.jokers {
+				t.Errorf("jokers: %d, want %d", jokers, tc.jokers)
+			}
+			if aceOfClubs != tc.aceOfClubs {
+				t.Errorf("aces of clubs: %d, want %d", aceOfClubs, tc.aceOfClubs)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/variant"
+)
+
+// maxFixedLimitBets caps a fixed limit betting round at a bet and three raises.
+const maxFixedLimitBets = 4
+
+// Betting is a betting round as seen by the player to act. The rules of what a
+// player can bet are the same in a round dealt to players and in a simulation.
+type Betting struct {
+	Limit variant.Limit
+
+	// Size is the least bet, the bet of the street in fixed limit.
+	Size int
+
+	// MaxBet is the most chips bet by a player in the betting round, MinRaise the
+	// least raise, the last bet or raise.
+	MaxBet, MinRaise int
+
+	// Pot is the chips in the pot, the bets of the betting round included.
+	Pot int
+
+	// Closed reports whether the player cannot raise: every other player is
+	// all-in, or the player has acted and the betting was not reopened.
+	Closed bool
+}
+
+// Actions returns the actions of a player who has bet the given chips in the
+// betting round, and has the given chips behind. Bets and raises are the least
+// chips to put in, up to Max in pot and fixed limit. Calling with all the chips is
+// a call, going all-in is for more or for less, within the limit.
+func (b Betting) Actions(bet, chips int) []player.Action {
+	toCall := max(b.MaxBet-bet, 0)
+	actions := make([]player.Action, 0, 4)
+	if toCall > 0 {
+		actions = append(actions, player.Action{Type: player.ActionFold})
+	} else {
+		actions = append(actions, player.Action{Type: player.ActionCheck})
+	}
+	if toCall > 0 && chips >= toCall {
+		actions = append(actions, player.Action{Type: player.ActionCall, Chips: toCall})
+	}
+	if b.Closed {
+		if chips < toCall {
+			actions = append(actions, player.Action{Type: player.ActionAllIn, Chips: chips})
+		}
+		return actions
+	}
+
+	least, most := toCall+max(b.MinRaise, b.Size), chips
+	switch b.Limit {
+	case variant.PotLimit:
+		// the most a player can bet is the pot after calling
+		most = b.Pot + 2*toCall
+	case variant.FixedLimit:
+		// bets and raises are of the bet size, completing the bring-in counts as
+		// the bet
+		least = (b.MaxBet/b.Size+1)*b.Size - bet
+		most = least
+		if b.MaxBet/b.Size >= maxFixedLimitBets {
+			most = toCall
+		}
+	}
+	if chips > least && least <= most {
+		action := player.Action{Type: player.ActionRaise, Chips: least}
+		if b.MaxBet == 0 {
+			action.Type = player.ActionBet
+		}
+		if b.Limit != variant.NoLimit {
+			action.Max = most
+		}
+		actions = append(actions, action)
+	}
+	if chips > 0 && chips != toCall && (chips < toCall || chips <= most) {
+		actions = append(actions, player.Action{Type: player.ActionAllIn, Chips: chips})
+	}
+	return actions
+}
+
+// BettingRound is a betting round at a table, by seat: the chips behind and bet,
+// who has folded and who has acted. It decides who acts next and what they can do,
+// the same in a hand dealt to players and in a simulation.
+type BettingRound struct {
+	limit variant.Limit
+	size  int
+
+	stacks, bets  []int
+	folded, acted []bool
+
+	// maxBet is the most chips bet by a seat, minRaise the least raise, the last
+	// full bet or raise.
+	maxBet, minRaise int
+}
+
+// NewBettingRound opens a betting round of the given bet size. The stacks are the
+// chips behind, the bets the live blinds already posted, and the seats folded are
+// out of the hand, empty seats included. The least raise is the bet size, or the
+// largest live blind, such as a straddle.
+func NewBettingRound(limit variant.Limit, size int, stacks, bets []int, folded []bool) BettingRound {
+	b := BettingRound{
+		limit:    limit,
+		size:     size,
+		stacks:   slices.Clone(stacks),
+		bets:     slices.Clone(bets),
+		folded:   slices.Clone(folded),
+		acted:    make([]bool, len(stacks)),
+		minRaise: size,
+	}
+	if len(bets) > 0 {
+		b.maxBet = slices.Max(bets)
+	}
+	b.minRaise = max(b.minRaise, b.maxBet)
+	return b
+}
+
+func (b BettingRound) clone() BettingRound {
+	b.stacks = slices.Clone(b.stacks)
+	b.bets = slices.Clone(b.bets)
+	b.folded = slices.Clone(b.folded)
+	b.acted = slices.Clone(b.acted)
+	return b
+}
+
+// inHand returns the number of seats which have not folded.
+func (b BettingRound) inHand() int {
+	count := 0
+	for _, folded := range b.folded {
+		if !folded {
+			count++
+		}
+	}
+	return count
+}
+
+// ToAct returns the first seat from the given one still to act, -1 once the
+// betting round is over. Players all-in do not act, nor does the last player with
+// chips on a street nobody has bet.
+func (b BettingRound) ToAct(from int) int {
+	if b.inHand() < 2 {
+		return -1
+	}
+	canAct := 0
+	for seat := range b.stacks {
+		if !b.folded[seat] && b.stacks[seat] > 0 {
+			canAct++
+		}
+	}
+	for i := range b.stacks {
+		seat := (from + i) % len(b.stacks)
+		if b.folded[seat] || b.stacks[seat] == 0 {
+			continue
+		}
+		if !b.acted[seat] && canAct > 1 || b.bets[seat] < b.maxBet {
+			return seat
+		}
+	}
+	return -1
+}
+
+// Betting returns the betting round as seen by the seat, the pot is the chips in
+// the hand, the bets of the betting round included. A player who has acted is
+// asked again only to call a raise too small to reopen the betting.
+func (b BettingRound) Betting(seat, pot int) Betting {
+	closed := true
+	for other := range b.stacks {
+		if other != seat && !b.folded[other] && b.stacks[other] > 0 {
+			closed = false
+		}
+	}
+	return Betting{
+		Limit:    b.limit,
+		Size:     b.size,
+		MaxBet:   b.maxBet,
+		MinRaise: b.minRaise,
+		Pot:      pot,
+		Closed:   closed || b.acted[seat],
+	}
+}
+
+// Actions returns the actions the seat can take.
+func (b BettingRound) Actions(seat, pot int) []player.Action {
+	return b.Betting(seat, pot).Actions(b.bets[seat], b.stacks[seat])
+}
+
+// Apply takes the action of the seat, the chips of a bet, call, raise or all-in
+// are the chips put in. A full raise reopens the betting, the others who have
+// acted only call or fold an all-in for less.
+func (b *BettingRound) Apply(seat int, action player.Action) {
+	b.acted[seat] = true
+	switch action.Type {
+	case player.ActionFold:
+		b.folded[seat] = true
+	case player.ActionCall, player.ActionBet, player.ActionRaise, player.ActionAllIn:
+		chips := min(action.Chips, b.stacks[seat])
+		b.stacks[seat] -= chips
+		b.bets[seat] += chips
+		raise := b.bets[seat] - b.maxBet
+		b.maxBet = max(b.maxBet, b.bets[seat])
+		if raise >= b.minRaise {
+			b.minRaise = raise
+			for other := range b.acted {
+				b.acted[other] = other == seat
+			}
+		}
+	}
+}
+
+// Stacks returns the chips behind of every seat.
+func (b BettingRound) Stacks() []int {
+	return slices.Clone(b.stacks)
+}
+
+// Bets returns the chips bet by every seat in the betting round.
+func (b BettingRound) Bets() []int {
+	return slices.Clone(b.bets)
+}
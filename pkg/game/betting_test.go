@@ -0,0 +1,107 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/variant"
+)
+
+func TestBettingActions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		betting    Betting
+		bet, chips int
+		want       []player.Action
+	}{
+		{
+			name:    "NoLimitOpen",
+			betting: Betting{Limit: variant.NoLimit, Size: 2, MinRaise: 2, Pot: 3},
+			chips:   100,
+			want: []player.Action{
+				{Type: player.ActionCheck},
+				{Type: player.ActionBet, Chips: 2},
+				{Type: player.ActionAllIn, Chips: 100},
+			},
+		},
+		{
+			// the pot of 13 after calling the 10 is 23, a raise of 23 puts in 33
+			name:    "PotLimitRaise",
+			betting: Betting{Limit: variant.PotLimit, Size: 2, MaxBet: 10, MinRaise: 10, Pot: 13},
+			chips:   100,
+			want: []player.Action{
+				{Type: player.ActionFold},
+				{Type: player.ActionCall, Chips: 10},
+				{Type: player.ActionRaise, Chips: 20, Max: 33},
+			},
+		},
+		{
+			name:    "FixedLimitRaise",
+			betting: Betting{Limit: variant.FixedLimit, Size: 2, MaxBet: 2, MinRaise: 2, Pot: 3},
+			chips:   100,
+			want: []player.Action{
+				{Type: player.ActionFold},
+				{Type: player.ActionCall, Chips: 2},
+				{Type: player.ActionRaise, Chips: 4, Max: 4},
+			},
+		},
+		{
+			name:    "FixedLimitCapped",
+			betting: Betting{Limit: variant.FixedLimit, Size: 2, MaxBet: 8, MinRaise: 2, Pot: 19},
+			bet:     2,
+			chips:   100,
+			want: []player.Action{
+				{Type: player.ActionFold},
+				{Type: player.ActionCall, Chips: 6},
+			},
+		},
+		{
+			name:    "Closed",
+			betting: Betting{Limit: variant.NoLimit, Size: 2, MaxBet: 50, MinRaise: 48, Pot: 53, Closed: true},
+			chips:   30,
+			want: []player.Action{
+				{Type: player.ActionFold},
+				{Type: player.ActionAllIn, Chips: 30},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.betting.Actions(tc.bet, tc.chips)
+			if len(got) != len(tc.want) {
+				t.Fatalf("Actions() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i].Type != tc.want[i].Type || got[i].Chips != tc.want[i].Chips || got[i].Max != tc.want[i].Max {
+					t.Errorf("Actions()[%d] = %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBettingRoundStraddle(t *testing.T) {
+	// the button, the blinds and a straddle of twice the big blind
+	b := NewBettingRound(variant.NoLimit, 2, []int{100, 99, 98, 96}, []int{0, 1, 2, 4}, make([]bool, 4))
+	if seat := b.ToAct(0); seat != 0 {
+		t.Fatalf("ToAct(0) = %d, want the button left of the straddle", seat)
+	}
+	got := b.Actions(0, 7)
+	if raise := got[2]; raise.Type != player.ActionRaise || raise.Chips != 8 {
+		t.Errorf("Actions()[2] = %v, want a raise to twice the straddle", raise)
+	}
+
+	seat := 0
+	for range 3 {
+		b.Apply(seat, player.Action{Type: player.ActionCall, Chips: 4 - b.Bets()[seat]})
+		seat = b.ToAct(seat + 1)
+	}
+	if seat != 3 {
+		t.Fatalf("ToAct() = %d, want the straddle to have the option", seat)
+	}
+	b.Apply(seat, player.Action{Type: player.ActionCheck})
+	if seat := b.ToAct(0); seat != -1 {
+		t.Errorf("ToAct() = %d, want the betting round over", seat)
+	}
+}
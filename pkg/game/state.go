@@ -0,0 +1,449 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/hand"
+	"github.com/yshngg/holdem/pkg/player"
+	pots "github.com/yshngg/holdem/pkg/pot"
+	"github.com/yshngg/holdem/pkg/variant"
+)
+
+// Street is the betting round of the hand.
+type Street int
+
+const (
+	PreFlop Street = iota
+	Flop
+	Turn
+	River
+	Showdown
+)
+
+func (s Street) String() string {
+	switch s {
+	case PreFlop:
+		return "PreFlop"
+	case Flop:
+		return "Flop"
+	case Turn:
+		return "Turn"
+	case River:
+		return "River"
+	case Showdown:
+		return "Showdown"
+	default:
+		return "Invalid"
+	}
+}
+
+const MinPlayerCount = 2
+
+type ErrNotEnoughCards struct {
+	count, want int
+}
+
+func (e ErrNotEnoughCards) Error() string {
+	return fmt.Sprintf("not enough cards: %d, want %d", e.count, e.want)
+}
+
+type ErrInvalidConfig struct {
+	config Config
+}
+
+func (e ErrInvalidConfig) Error() string {
+	return fmt.Sprintf("invalid config: %+v", e.config)
+}
+
+type ErrVariantNotSupported struct {
+	variant variant.Variant
+}
+
+func (e ErrVariantNotSupported) Error() string {
+	return fmt.Sprintf("variant not supported: %s", e.variant)
+}
+
+type ErrIllegalAction struct {
+	action player.Action
+}
+
+func (e ErrIllegalAction) Error() string {
+	return fmt.Sprintf("illegal action: %v %d", e.action.Type, e.action.Chips)
+}
+
+type ErrTerminal struct{}
+
+func (e ErrTerminal) Error() string {
+	return "hand is over"
+}
+
+type ErrNotTerminal struct {
+	street Street
+}
+
+func (e ErrNotTerminal) Error() string {
+	return fmt.Sprintf("hand is not over, street: %s", e.street)
+}
+
+// Config is the game, the stakes and the stacks of a hand.
+type Config struct {
+	// Variant is a game of hole cards and a board of flop, turn and river,
+	// Hold'em if nil.
+	Variant variant.Variant
+	Limit   variant.Limit
+
+	SmallBlind, BigBlind, Ante int
+
+	// Button is the seat of the button. Heads-up, the button posts the small blind.
+	Button int
+
+	// Stacks are the chips of every seat at the start of the hand.
+	Stacks []int
+}
+
+// State is a hand of a community card game, immutable: applying an action returns
+// the next state and leaves the state as it was. It holds the rules of the game
+// and nothing else, no clock and no player to wait for.
+type State struct {
+	config  Config
+	streets []variant.Street
+
+	// deck is the cards to deal in order, from the left of the button.
+	deck      []card.Card
+	holeCards [][]card.Card
+	board     []card.Card
+
+	street Street
+
+	// round is the betting round of the street, with the chips behind of every
+	// seat. committed is the chips put in the hand, antes included.
+	round     BettingRound
+	committed []int
+
+	toAct int
+
+	history []player.Action
+}
+
+// New deals a hand from the deck, in order, and posts the antes and the blinds. A
+// short stack posts what it has.
+func New(config Config, deck []card.Card) (State, error) {
+	if config.Variant == nil {
+		config.Variant = variant.Holdem
+	}
+	if len(config.Stacks) < MinPlayerCount || config.SmallBlind <= 0 || config.BigBlind < config.SmallBlind ||
+		config.Ante < 0 || config.Button < 0 || config.Button >= len(config.Stacks) || slices.Min(config.Stacks) <= 0 {
+		return State{}, ErrInvalidConfig{config: config}
+	}
+	streets := config.Variant.Streets()
+	if !supported(streets) {
+		return State{}, ErrVariantNotSupported{variant: config.Variant}
+	}
+	count := len(config.Stacks)
+	want := count*streets[0].Hole + len(streets) - 1
+	for _, street := range streets {
+		want += street.Board
+	}
+	if len(deck) < want {
+		return State{}, ErrNotEnoughCards{count: len(deck), want: want}
+	}
+
+	config.Stacks = slices.Clone(config.Stacks)
+	s := State{
+		config:    config,
+		streets:   streets,
+		deck:      slices.Clone(deck),
+		holeCards: make([][]card.Card, count),
+		committed: make([]int, count),
+	}
+	// one card at a time from the left of the button
+	for range streets[0].Hole {
+		for i := range count {
+			seat := s.seat(1 + i)
+			s.holeCards[seat] = append(s.holeCards[seat], s.deal())
+		}
+	}
+	stacks, bets := slices.Clone(config.Stacks), make([]int, count)
+	post := func(seat, chips int) {
+		chips = min(chips, stacks[seat])
+		stacks[seat] -= chips
+		s.committed[seat] += chips
+		bets[seat] += chips
+	}
+	for seat := range count {
+		post(seat, config.Ante)
+	}
+	// the antes are dead, they do not count toward the bets
+	clear(bets)
+	smallBlind := s.seat(1)
+	if count == 2 {
+		smallBlind = config.Button
+	}
+	post(smallBlind, config.SmallBlind)
+	post(s.next(smallBlind), config.BigBlind)
+	s.round = NewBettingRound(config.Limit, s.size(), stacks, bets, make([]bool, count))
+	s.advance(s.next(s.next(smallBlind)))
+	return s, nil
+}
+
+// supported reports whether the streets are the hole cards, then board cards only:
+// the flop, the turn and the river.
+func supported(streets []variant.Street) bool {
+	if len(streets) != int(Showdown) || streets[0] != (variant.Street{Hole: streets[0].Hole}) {
+		return false
+	}
+	for _, street := range streets[1:] {
+		if street != (variant.Street{Board: street.Board, BigBet: street.BigBet}) {
+			return false
+		}
+	}
+	return true
+}
+
+// seat returns the seat at the given offset from the button.
+func (s State) seat(offset int) int {
+	return (s.config.Button + offset) % len(s.committed)
+}
+
+// next returns the seat left of the given one.
+func (s State) next(seat int) int {
+	return (seat + 1) % len(s.committed)
+}
+
+// size returns the bet size of the street, doubled on the big bet streets of fixed
+// limit.
+func (s State) size() int {
+	if s.config.Limit == variant.FixedLimit && s.streets[s.street].BigBet {
+		return s.config.BigBlind * 2
+	}
+	return s.config.BigBlind
+}
+
+func (s *State) deal() card.Card {
+	c := s.deck[0]
+	s.deck = s.deck[1:]
+	return c
+}
+
+// Street returns the betting round, Showdown once the hand is over.
+func (s State) Street() Street {
+	return s.street
+}
+
+// Players returns the number of seats dealt in.
+func (s State) Players() int {
+	return len(s.committed)
+}
+
+// ToAct returns the seat to act.
+func (s State) ToAct() int {
+	return s.toAct
+}
+
+// HoleCards returns the hole cards of the seat.
+func (s State) HoleCards(seat int) []card.Card {
+	return slices.Clone(s.holeCards[seat])
+}
+
+// Board returns the community cards dealt.
+func (s State) Board() []card.Card {
+	return slices.Clone(s.board)
+}
+
+// Stacks returns the chips behind of every seat.
+func (s State) Stacks() []int {
+	return s.round.Stacks()
+}
+
+// Bets returns the chips bet on the street by every seat.
+func (s State) Bets() []int {
+	return s.round.Bets()
+}
+
+// Pot returns the chips put in the hand.
+func (s State) Pot() int {
+	pot := 0
+	for _, chips := range s.committed {
+		pot += chips
+	}
+	return pot
+}
+
+// History returns the actions taken in the hand, in order.
+func (s State) History() []player.Action {
+	return slices.Clone(s.history)
+}
+
+// IsTerminal reports whether the hand is over, all players but one have folded or
+// the hands have been shown down.
+func (s State) IsTerminal() bool {
+	return s.street == Showdown
+}
+
+// Betting returns the betting round as seen by the seat to act.
+func (s State) Betting() Betting {
+	return s.round.Betting(s.toAct, s.Pot())
+}
+
+// LegalActions returns the actions the seat to act can take, none once the hand is
+// over.
+func (s State) LegalActions() []player.Action {
+	if s.IsTerminal() {
+		return nil
+	}
+	return s.round.Actions(s.toAct, s.Pot())
+}
+
+// Apply returns the state after the seat to act takes the action. The chips of a
+// bet or raise are the chips put in, between the least legal and the limit; the
+// chips of the other actions are filled in.
+func (s State) Apply(action player.Action) (State, error) {
+	if s.IsTerminal() {
+		return s, ErrTerminal{}
+	}
+	seat, actions := s.toAct, s.LegalActions()
+	i := slices.IndexFunc(actions, func(a player.Action) bool {
+		return a.Type == action.Type
+	})
+	if i < 0 {
+		return s, ErrIllegalAction{action: action}
+	}
+	legal := actions[i]
+	switch action.Type {
+	case player.ActionBet, player.ActionRaise:
+		if action.Chips < legal.Chips || action.Chips > s.round.stacks[seat] || legal.Max > 0 && action.Chips > legal.Max {
+			return s, ErrIllegalAction{action: action}
+		}
+	default:
+		action.Chips = legal.Chips
+	}
+
+	next := s.clone()
+	next.history = append(next.history, action)
+	next.round.Apply(seat, action)
+	next.committed[seat] += s.round.stacks[seat] - next.round.stacks[seat]
+	next.advance(next.next(seat))
+	return next, nil
+}
+
+func (s State) clone() State {
+	s.board = slices.Clone(s.board)
+	s.round = s.round.clone()
+	s.committed = slices.Clone(s.committed)
+	s.history = slices.Clone(s.history)
+	return s
+}
+
+// advance passes the action to the first seat from the given one still to act,
+// and deals the next streets once the betting round is over.
+func (s *State) advance(from int) {
+	for {
+		if s.round.inHand() == 1 {
+			s.street = Showdown
+			return
+		}
+		if seat := s.round.ToAct(from); seat >= 0 {
+			s.toAct = seat
+			return
+		}
+		if s.street == River {
+			s.street = Showdown
+			return
+		}
+		s.nextStreet()
+		from = s.seat(1)
+	}
+}
+
+// nextStreet burns a card and deals the board of the next street.
+func (s *State) nextStreet() {
+	s.street++
+	s.deck = s.deck[1:] // burn
+	for range s.streets[s.street].Board {
+		s.board = append(s.board, s.deal())
+	}
+	s.round = NewBettingRound(s.config.Limit, s.size(), s.round.stacks, make([]int, len(s.committed)), s.round.folded)
+}
+
+// Payoffs returns the chips every seat has won or lost in the hand, zero-sum. The
+// pots are split between the best hands, ordered from the left of the button, and
+// the uncalled chips go back to the bettor.
+func (s State) Payoffs() ([]int, error) {
+	if !s.IsTerminal() {
+		return nil, ErrNotTerminal{street: s.street}
+	}
+	scores := make([]hand.Score, len(s.committed))
+	lows := make([]hand.Score, len(s.committed))
+	if s.round.inHand() > 1 {
+		for seat := range s.committed {
+			if s.round.folded[seat] {
+				continue
+			}
+			score, _, err := s.config.Variant.Evaluator()(s.holeCards[seat], s.board)
+			if err != nil {
+				return nil, fmt.Errorf("evaluate hand of seat %d, err: %w", seat, err)
+			}
+			scores[seat] = score
+			if evaluate := s.config.Variant.Low(); evaluate != nil {
+				if lows[seat], _, err = evaluate(s.holeCards[seat], s.board); err != nil {
+					return nil, fmt.Errorf("evaluate low of seat %d, err: %w", seat, err)
+				}
+			}
+		}
+	}
+
+	p := pots.New()
+	for seat, chips := range s.committed {
+		p.AddChips(strconv.Itoa(seat), chips)
+	}
+	payoffs := make([]int, len(s.committed))
+	for seat, chips := range s.committed {
+		payoffs[seat] = -chips
+	}
+	for _, pot := range p.Settle() {
+		contenders := make([]int, 0, len(s.committed))
+		for i := range s.committed {
+			seat := s.seat(1 + i)
+			if _, ok := pot.Contributors()[strconv.Itoa(seat)]; ok && !s.round.folded[seat] {
+				contenders = append(contenders, seat)
+			}
+		}
+		if len(contenders) == 0 {
+			// the chips of players who all folded go to those left in the hand
+			for i := range s.committed {
+				if seat := s.seat(1 + i); !s.round.folded[seat] {
+					contenders = append(contenders, seat)
+				}
+			}
+		}
+		high, low := best(scores, contenders), best(lows, contenders)
+		if len(contenders) == 1 {
+			high, low = []string{strconv.Itoa(contenders[0])}, nil
+		}
+		for id, chips := range pots.Split(pot.Chips(), high, low) {
+			seat, _ := strconv.Atoi(id)
+			payoffs[seat] += chips
+		}
+	}
+	return payoffs, nil
+}
+
+// best returns the seats of the contenders with the best score, in order, none if
+// no score qualifies.
+func best(scores []hand.Score, contenders []int) []string {
+	var top hand.Score
+	winners := make([]string, 0)
+	for _, seat := range contenders {
+		switch score := scores[seat]; {
+		case score == 0:
+		case score > top:
+			top, winners = score, []string{strconv.Itoa(seat)}
+		case score == top:
+			winners = append(winners, strconv.Itoa(seat))
+		}
+	}
+	return winners
+}
@@ -0,0 +1,153 @@
+package game
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+	"github.com/yshngg/holdem/pkg/variant"
+)
+
+// threeHanded deals aces to the small blind, kings to the button and a seven-deuce
+// to the big blind, on a board of no help to anyone.
+var threeHanded = []card.Card{
+	card.New(rank.Ace, suit.Clubs),    // small blind
+	card.New(rank.Seven, suit.Clubs),  // big blind
+	card.New(rank.King, suit.Clubs),   // button
+	card.New(rank.Ace, suit.Hearts),   // small blind
+	card.New(rank.Two, suit.Hearts),   // big blind
+	card.New(rank.King, suit.Hearts),  // button
+	card.New(rank.Three, suit.Spades), // burn
+	card.New(rank.Jack, suit.Spades),
+	card.New(rank.Queen, suit.Diamonds),
+	card.New(rank.Nine, suit.Clubs),
+	card.New(rank.Four, suit.Spades), // burn
+	card.New(rank.Five, suit.Diamonds),
+	card.New(rank.Six, suit.Spades), // burn
+	card.New(rank.Three, suit.Hearts),
+}
+
+var config = Config{SmallBlind: 1, BigBlind: 2, Button: 0, Stacks: []int{100, 20, 100}}
+
+func apply(t *testing.T, s State, actions ...player.Action) State {
+	t.Helper()
+	for _, action := range actions {
+		next, err := s.Apply(action)
+		if err != nil {
+			t.Fatalf("Apply(%v).err = %v", action, err)
+		}
+		s = next
+	}
+	return s
+}
+
+func TestNew(t *testing.T) {
+	s, err := New(config, threeHanded)
+	if err != nil {
+		t.Fatalf("New().err = %v", err)
+	}
+	if s.ToAct() != 0 {
+		t.Errorf("ToAct() = %d, want the button left of the big blind", s.ToAct())
+	}
+	if !slices.Equal(s.Stacks(), []int{100, 19, 98}) {
+		t.Errorf("Stacks() = %v, want the blinds posted", s.Stacks())
+	}
+	if got := s.HoleCards(1); !slices.Equal(got, []card.Card{threeHanded[0], threeHanded[3]}) {
+		t.Errorf("HoleCards(1) = %v, want the first card from the left of the button", got)
+	}
+
+	testCases := []struct {
+		name   string
+		config Config
+		deck   []card.Card
+		want   error
+	}{
+		{name: "OnePlayer", config: Config{SmallBlind: 1, BigBlind: 2, Stacks: []int{100}}, deck: threeHanded, want: ErrInvalidConfig{}},
+		{name: "Button", config: Config{SmallBlind: 1, BigBlind: 2, Button: 3, Stacks: []int{100, 100}}, deck: threeHanded, want: ErrInvalidConfig{}},
+		{name: "Stud", config: Config{Variant: variant.SevenCardStud, SmallBlind: 1, BigBlind: 2, Stacks: []int{100, 100}}, deck: threeHanded, want: ErrVariantNotSupported{}},
+		{name: "NotEnoughCards", config: config, deck: threeHanded[:13], want: ErrNotEnoughCards{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.config, tc.deck)
+			if reflect.TypeOf(err) != reflect.TypeOf(tc.want) {
+				t.Errorf("New().err = %v, want %T", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestSidePot(t *testing.T) {
+	s, err := New(config, threeHanded)
+	if err != nil {
+		t.Fatalf("New().err = %v", err)
+	}
+	s = apply(t, s, player.Action{Type: player.ActionAllIn})
+	s = apply(t, s, player.Action{Type: player.ActionAllIn})
+	if s.IsTerminal() {
+		t.Fatalf("IsTerminal() = true, want the big blind to act")
+	}
+	s = apply(t, s, player.Action{Type: player.ActionCall})
+	if !s.IsTerminal() || len(s.Board()) != 5 {
+		t.Fatalf("IsTerminal() = %v with %v, want the board run out", s.IsTerminal(), s.Board())
+	}
+
+	payoffs, err := s.Payoffs()
+	if err != nil {
+		t.Fatalf("Payoffs().err = %v", err)
+	}
+	// the aces win the main pot of 60, the kings the side pot of 160
+	if want := []int{60, 40, -100}; !slices.Equal(payoffs, want) {
+		t.Errorf("Payoffs() = %v, want %v", payoffs, want)
+	}
+}
+
+func TestFold(t *testing.T) {
+	s, err := New(config, threeHanded)
+	if err != nil {
+		t.Fatalf("New().err = %v", err)
+	}
+	if _, err := s.Payoffs(); err != (ErrNotTerminal{street: PreFlop}) {
+		t.Errorf("Payoffs().err = %v, want %v", err, ErrNotTerminal{street: PreFlop})
+	}
+	raised := apply(t, s, player.Action{Type: player.ActionRaise, Chips: 6})
+	if s.Pot() != 3 || raised.Pot() != 9 {
+		t.Errorf("Pot() = %d then %d, want 3 then 9", s.Pot(), raised.Pot())
+	}
+	s = apply(t, raised, player.Action{Type: player.ActionFold}, player.Action{Type: player.ActionFold})
+	payoffs, err := s.Payoffs()
+	if err != nil {
+		t.Fatalf("Payoffs().err = %v", err)
+	}
+	if want := []int{3, -1, -2}; !slices.Equal(payoffs, want) {
+		t.Errorf("Payoffs() = %v, want %v", payoffs, want)
+	}
+}
+
+func TestIncompleteRaise(t *testing.T) {
+	s, err := New(config, threeHanded)
+	if err != nil {
+		t.Fatalf("New().err = %v", err)
+	}
+	// the button raises by 10 and the small blind is all-in for 8 more
+	s = apply(t, s, player.Action{Type: player.ActionRaise, Chips: 12}, player.Action{Type: player.ActionAllIn})
+	if got := s.LegalActions(); got[len(got)-1].Type != player.ActionAllIn || s.ToAct() != 2 {
+		t.Fatalf("LegalActions() = %v for seat %d, want the big blind free to raise", got, s.ToAct())
+	}
+	s = apply(t, s, player.Action{Type: player.ActionCall})
+	if s.ToAct() != 0 {
+		t.Fatalf("ToAct() = %d, want the button to call the all-in", s.ToAct())
+	}
+	var got []player.ActionType
+	for _, action := range s.LegalActions() {
+		got = append(got, action.Type)
+	}
+	if want := []player.ActionType{player.ActionFold, player.ActionCall}; !slices.Equal(got, want) {
+		t.Errorf("LegalActions() = %v, want %v, an all-in for less does not reopen the betting", got, want)
+	}
+}
@@ -0,0 +1,54 @@
+package hand
+
+import (
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+)
+
+// EvaluateDeuceToSeven scores five cards as a deuce-to-seven low: the ace is always
+// high, straights and flushes count against the hand, and the best hand is 7-5-4-3-2
+// unsuited. The lower the hand, the higher the score.
+func EvaluateDeuceToSeven(cards []card.Card) (Score, error) {
+	value, err := Value(cards)
+	if err != nil {
+		return 0, err
+	}
+	if value == Invalid {
+		return 0, ErrUnknownHandValue{}
+	}
+	ranks := kickers(value, cards)
+	if wheel(cards) {
+		// A 2 3 4 5 is ace high, not a straight
+		value, ranks = HighCard, kickers(HighCard, cards)
+		if isFlush(cards) {
+			value = Flush
+		}
+	}
+	return 1<<(7*kickerBits) - score(value, value, ranks), nil
+}
+
+// DeuceToSeven makes the best deuce-to-seven low out of the hole cards, there is no
+// board in draw games.
+func DeuceToSeven(holeCards, board []card.Card) (Score, []card.Card, error) {
+	cards := make([]card.Card, 0, len(holeCards)+len(board))
+	cards = append(append(cards, holeCards...), board...)
+	return bestOf(cards, EvaluateDeuceToSeven)
+}
+
+// wheel reports whether the cards are A-2-3-4-5.
+func wheel(cards []card.Card) bool {
+	ranks := make(map[rank.Rank]bool, len(cards))
+	for _, c := range cards {
+		ranks[c.Rank()] = true
+	}
+	return len(ranks) == 5 && ranks[rank.Ace] && ranks[rank.Two] && ranks[rank.Three] && ranks[rank.Four] && ranks[rank.Five]
+}
+
+func isFlush(cards []card.Card) bool {
+	for _, c := range cards[1:] {
+		if c.Suit() != cards[0].Suit() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+func TestEvaluateDeuceToSeven(t *testing.T) {
+	numberOne := []card.Card{
+		card.New(rank.Seven, suit.Hearts),
+		card.New(rank.Five, suit.Spades),
+		card.New(rank.Four, suit.Diamonds),
+		card.New(rank.Three, suit.Clubs),
+		card.New(rank.Two, suit.Spades),
+	}
+	eightLow := []card.Card{
+		card.New(rank.Eight, suit.Hearts),
+		card.New(rank.Five, suit.Spades),
+		card.New(rank.Four, suit.Diamonds),
+		card.New(rank.Three, suit.Clubs),
+		card.New(rank.Two, suit.Spades),
+	}
+	testCases := []struct {
+		name   string
+		better []card.Card
+		worse  []card.Card
+	}{
+		{name: "SevenBeatsEight", better: numberOne, worse: eightLow},
+		{
+			name:   "WheelIsAceHigh",
+			better: eightLow,
+			worse: []card.Card{
+				card.New(rank.Ace, suit.Hearts),
+				card.New(rank.Five, suit.Spades),
+				card.New(rank.Four, suit.Diamonds),
+				card.New(rank.Three, suit.Clubs),
+				card.New(rank.Two, suit.Spades),
+			},
+		},
+		{
+			name:   "StraightCounts",
+			better: eightLow,
+			worse: []card.Card{
+				card.New(rank.Six, suit.Hearts),
+				card.New(rank.Five, suit.Spades),
+				card.New(rank.Four, suit.Diamonds),
+				card.New(rank.Three, suit.Clubs),
+				card.New(rank.Two, suit.Spades),
+			},
+		},
+		{
+			name:   "FlushCounts",
+			better: eightLow,
+			worse: []card.Card{
+				card.New(rank.Seven, suit.Spades),
+				card.New(rank.Five, suit.Spades),
+				card.New(rank.Four, suit.Spades),
+				card.New(rank.Three, suit.Spades),
+				card.New(rank.Two, suit.Spades),
+			},
+		},
+		{
+			name: "KingHighBeatsPair",
+			better: []card.Card{
+				card.New(rank.King, suit.Hearts),
+				card.New(rank.Queen, suit.Spades),
+				card.New(rank.Jack, suit.Diamonds),
+				card.New(rank.Ten, suit.Clubs),
+				card.New(rank.Eight, suit.Spades),
+			},
+			worse: []card.Card{
+				card.New(rank.Two, suit.Hearts),
+				card.New(rank.Two, suit.Spades),
+				card.New(rank.Four, suit.Diamonds),
+				card.New(rank.Three, suit.Clubs),
+				card.New(rank.Five, suit.Spades),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			better, err := EvaluateDeuceToSeven(tc.better)
+			if err != nil {
+				t.Fatalf("EvaluateDeuceToSeven(%v).err = %v", tc.better, err)
+			}
+			worse, err := EvaluateDeuceToSeven(tc.worse)
+			if err != nil {
+				t.Fatalf("EvaluateDeuceToSeven(%v).err = %v", tc.worse, err)
+			}
+			if better <= worse {
+				t.Errorf("EvaluateDeuceToSeven(%v) = %d, want greater than EvaluateDeuceToSeven(%v) = %d", tc.better, better, tc.worse, worse)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package hand
+
+import (
+	"math/rand"
+
+	"github.com/yshngg/holdem/pkg/card"
+)
+
+const (
+	// maxExactBoards is the most board completions the exact equity is computed over.
+	maxExactBoards = 20000
+
+	defaultTrials = 20000
+)
+
+// Equity returns the share of the pot every hand wins on average, over the ways to
+// complete the board to five cards from the remaining cards, ties split the share.
+// The hands are made by the evaluators of the game, in split-pot games the high
+// hands share half of the pot and the qualifying low hands the other half, low is
+// nil in the other games.
+// It is computed exactly when there are few completions, such as on the flop or the
+// turn, and approximated by Monte Carlo simulation otherwise.
+func Equity(high, low Evaluator, hands [][]card.Card, board, remaining []card.Card) ([]float64, error) {
+	missing := 5 - len(board)
+	if missing < 0 || missing > len(remaining) {
+		return nil, ErrInvalidCardCount{count: len(board)}
+	}
+	if binomial(len(remaining), missing) > maxExactBoards {
+		return MonteCarloEquity(high, low, hands, board, remaining, defaultTrials)
+	}
+
+	equities := make([]float64, len(hands))
+	completions := combinations(len(remaining), missing)
+	for _, completion := range completions {
+		complete := append(make([]card.Card, 0, 5), board...)
+		for _, i := range completion {
+			complete = append(complete, remaining[i])
+		}
+		if err := share(high, low, equities, hands, complete); err != nil {
+			return nil, err
+		}
+	}
+	for i := range equities {
+		equities[i] /= float64(len(completions))
+	}
+	return equities, nil
+}
+
+// MonteCarloEquity approximates the equities by completing the board at random
+// for the given number of trials.
+func MonteCarloEquity(high, low Evaluator, hands [][]card.Card, board, remaining []card.Card, trials int) ([]float64, error) {
+	missing := 5 - len(board)
+	if missing < 0 || missing > len(remaining) {
+		return nil, ErrInvalidCardCount{count: len(board)}
+	}
+	equities := make([]float64, len(hands))
+	if trials <= 0 {
+		return equities, nil
+	}
+	deck := append([]card.Card(nil), remaining...)
+	for range trials {
+		// a partial shuffle draws the missing cards to the front of the deck
+		for i := range missing {
+			j := i + rand.Intn(len(deck)-i)
+			deck[i], deck[j] = deck[j], deck[i]
+		}
+		complete := append(append(make([]card.Card, 0, 5), board...), deck[:missing]...)
+		if err := share(high, low, equities, hands, complete); err != nil {
+			return nil, err
+		}
+	}
+	for i := range equities {
+		equities[i] /= float64(trials)
+	}
+	return equities, nil
+}
+
+// share adds the share of the pot every hand wins on the complete board.
+func share(high, low Evaluator, equities []float64, hands [][]card.Card, board []card.Card) error {
+	highWinners, err := best(high, hands, board)
+	if err != nil {
+		return err
+	}
+	var lowWinners []int
+	if low != nil {
+		if lowWinners, err = best(low, hands, board); err != nil {
+			return err
+		}
+	}
+	half := 1.0
+	if len(lowWinners) > 0 {
+		half = 0.5
+		for _, i := range lowWinners {
+			equities[i] += half / float64(len(lowWinners))
+		}
+	}
+	for _, i := range highWinners {
+		equities[i] += half / float64(len(highWinners))
+	}
+	return nil
+}
+
+// best returns the hands scoring the highest, none if no hand scores, such as when
+// no one qualifies for the low.
+func best(evaluate Evaluator, hands [][]card.Card, board []card.Card) ([]int, error) {
+	var best Score
+	winners := make([]int, 0, len(hands))
+	for i, hand := range hands {
+		score, _, err := evaluate(hand, board)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case score == 0:
+		case score > best:
+			best, winners = score, append(winners[:0], i)
+		case score == best:
+			winners = append(winners, i)
+		}
+	}
+	return winners, nil
+}
+
+func binomial(n, k int) int {
+	result := 1
+	for i := range k {
+		result = result * (n - i) / (i + 1)
+	}
+	return result
+}
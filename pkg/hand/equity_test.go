@@ -0,0 +1,76 @@
+package hand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/deck"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+func TestEquity(t *testing.T) {
+	hands := [][]card.Card{
+		{card.New(rank.Ace, suit.Hearts), card.New(rank.Ace, suit.Diamonds)},
+		{card.New(rank.King, suit.Hearts), card.New(rank.King, suit.Diamonds)},
+	}
+	board := []card.Card{
+		card.New(rank.Two, suit.Clubs),
+		card.New(rank.Seven, suit.Spades),
+		card.New(rank.Nine, suit.Hearts),
+		card.New(rank.Jack, suit.Diamonds),
+	}
+	testCases := []struct {
+		name      string
+		remaining []card.Card
+		want      []float64
+	}{
+		{
+			name:      "OneOut",
+			remaining: []card.Card{card.New(rank.King, suit.Clubs), card.New(rank.Three, suit.Spades)},
+			want:      []float64{0.5, 0.5},
+		},
+		{
+			name:      "DrawingDead",
+			remaining: []card.Card{card.New(rank.Three, suit.Spades), card.New(rank.Four, suit.Spades)},
+			want:      []float64{1, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Equity(Holdem, nil, hands, board, tc.remaining)
+			if err != nil {
+				t.Fatalf("Equity(%v).err = %v", tc.remaining, err)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tc.want[i]) > 1e-9 {
+					t.Errorf("Equity(%v) = %v, want %v", tc.remaining, got, tc.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestMonteCarloEquity(t *testing.T) {
+	hands := [][]card.Card{
+		{card.New(rank.Ace, suit.Hearts), card.New(rank.King, suit.Hearts)},
+		{card.New(rank.Ace, suit.Spades), card.New(rank.King, suit.Spades)},
+	}
+	remaining := make([]card.Card, 0)
+	for _, c := range deck.New().List() {
+		if c.Rank() != rank.Ace && c.Rank() != rank.King {
+			remaining = append(remaining, c)
+		}
+	}
+	got, err := MonteCarloEquity(Holdem, nil, hands, nil, remaining, 2000)
+	if err != nil {
+		t.Fatalf("MonteCarloEquity().err = %v", err)
+	}
+	// without an ace or a king left, the hands split unless a flush comes
+	if math.Abs(got[0]-got[1]) > 0.05 || got[0]+got[1] < 0.999 {
+		t.Errorf("MonteCarloEquity() = %v, want about even", got)
+	}
+}
@@ -0,0 +1,54 @@
+package hand
+
+import "github.com/yshngg/holdem/pkg/card"
+
+// Evaluator scores the best hand a player makes out of the hole cards and the board,
+// it returns the cards making the hand.
+type Evaluator func(holeCards, board []card.Card) (Score, []card.Card, error)
+
+// Holdem makes the best hand out of any of the hole cards and the board cards.
+func Holdem(holeCards, board []card.Card) (Score, []card.Card, error) {
+	cards := make([]card.Card, 0, len(holeCards)+len(board))
+	cards = append(append(cards, holeCards...), board...)
+	return Best(cards)
+}
+
+// Omaha makes the best hand out of exactly two hole cards and three board cards.
+func Omaha(holeCards, board []card.Card) (Score, []card.Card, error) {
+	return BestUsing(holeCards, board, 2)
+}
+
+// BestUsing makes the best hand out of exactly the given number of hole cards,
+// and the rest out of the board cards.
+func BestUsing(holeCards, board []card.Card, fromHole int) (Score, []card.Card, error) {
+	if fromHole < 0 || fromHole > 5 || len(holeCards) < fromHole || len(board) < 5-fromHole {
+		return 0, nil, ErrInvalidCardCount{count: len(holeCards) + len(board)}
+	}
+	cards := make([]card.Card, 0, len(holeCards)+len(board))
+	if existSameCards(append(append(cards, holeCards...), board...)) {
+		return 0, nil, ErrExistSameCards{}
+	}
+	var (
+		best     Score
+		bestHand []card.Card
+	)
+	for _, fromHand := range combinations(len(holeCards), fromHole) {
+		for _, fromBoard := range combinations(len(board), 5-fromHole) {
+			hand := make([]card.Card, 0, 5)
+			for _, i := range fromHand {
+				hand = append(hand, holeCards[i])
+			}
+			for _, i := range fromBoard {
+				hand = append(hand, board[i])
+			}
+			s, err := Evaluate(hand)
+			if err != nil {
+				return 0, nil, err
+			}
+			if s > best {
+				best, bestHand = s, hand
+			}
+		}
+	}
+	return best, bestHand, nil
+}
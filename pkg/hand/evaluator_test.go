@@ -0,0 +1,47 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+func TestEvaluator(t *testing.T) {
+	holeCards := []card.Card{
+		card.New(rank.Ace, suit.Hearts),
+		card.New(rank.King, suit.Diamonds),
+		card.New(rank.Seven, suit.Clubs),
+		card.New(rank.Two, suit.Clubs),
+	}
+	board := []card.Card{
+		card.New(rank.Queen, suit.Hearts),
+		card.New(rank.Jack, suit.Hearts),
+		card.New(rank.Ten, suit.Hearts),
+		card.New(rank.Nine, suit.Hearts),
+		card.New(rank.Three, suit.Spades),
+	}
+	testCases := []struct {
+		name     string
+		evaluate Evaluator
+		want     Hand
+	}{
+		// the ace of hearts makes a flush with four hearts on board
+		{"Holdem", Holdem, Flush},
+		// a single heart in hand makes no flush, exactly two hole cards play
+		{"Omaha", Omaha, Straight},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			score, cards, err := tc.evaluate(holeCards, board)
+			if err != nil {
+				t.Fatalf("evaluate(%v, %v).err = %v", holeCards, board, err)
+			}
+			if score.Hand() != tc.want {
+				t.Errorf("evaluate(%v, %v) = %v (%v), want %v", holeCards, board, score.Hand(), cards, tc.want)
+			}
+		})
+	}
+}
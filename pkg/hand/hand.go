@@ -19,6 +19,7 @@ const (
 	FourOfAKind        // Four cards of the same value
 	StraightFlush      // Straight of the same suit
 	RoyalFlush         // Highest straight of the same suit
+	FiveOfAKind        // Five cards with the same value, with wild cards or several decks
 )
 
 func (hv Hand) String() string {
@@ -43,6 +44,8 @@ func (hv Hand) String() string {
 		return "Straight Flush"
 	case RoyalFlush:
 		return "Royal Flush"
+	case FiveOfAKind:
+		return "Five of a Kind"
 	default:
 		return "Invalid"
 	}
@@ -78,14 +81,22 @@ func existSameCards(cards []card.Card) bool {
 }
 
 func Value(c []card.Card) (Hand, error) {
+	if len(c) != 5 {
+		return Invalid, ErrInvalidHandSize{}
+	}
+	if existSameCards(c) {
+		return Invalid, ErrExistSameCards{}
+	}
+	return value(c)
+}
+
+// value returns the value of five cards, which might come from several decks.
+func value(c []card.Card) (Hand, error) {
 	cards := make([]card.Card, len(c))
 	copy(cards, c)
 	if len(cards) != 5 {
 		return Invalid, ErrInvalidHandSize{}
 	}
-	if existSameCards(cards) {
-		return Invalid, ErrExistSameCards{}
-	}
 
 	m := make(map[rank.Rank]int)
 	for _, c := range cards {
@@ -93,7 +104,7 @@ func Value(c []card.Card) (Hand, error) {
 	}
 
 	if len(m) < 2 {
-		return Invalid, nil
+		return FiveOfAKind, nil
 	}
 
 	product := 1
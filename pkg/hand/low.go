@@ -0,0 +1,96 @@
+package hand
+
+import (
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+)
+
+// lowQualifier is the highest card a low hand may hold, eight-or-better.
+const lowQualifier = 8
+
+// Low scores five cards as an ace-to-five low, eight-or-better: the ace plays low,
+// straights and flushes do not count, and only five different ranks of eight or
+// lower qualify. The lower the hand, the higher the score, zero means no low.
+func Low(cards []card.Card) (Score, error) {
+	if len(cards) != 5 {
+		return 0, ErrInvalidHandSize{}
+	}
+	if existSameCards(cards) {
+		return 0, ErrExistSameCards{}
+	}
+	values := make([]int, 0, 5)
+	for _, c := range cards {
+		value := lowValue(c.Rank())
+		if value > lowQualifier || slices.Contains(values, value) {
+			return 0, nil
+		}
+		values = append(values, value)
+	}
+	// compared from the highest card down
+	slices.Sort(values)
+	slices.Reverse(values)
+	s := Score(0)
+	for _, value := range values {
+		s = s<<kickerBits | Score(value)
+	}
+	return 1<<(5*kickerBits) - s, nil
+}
+
+// HoldemLow makes the best low out of any of the hole cards and the board cards.
+func HoldemLow(holeCards, board []card.Card) (Score, []card.Card, error) {
+	cards := make([]card.Card, 0, len(holeCards)+len(board))
+	cards = append(append(cards, holeCards...), board...)
+	return bestLow(cards, nil, 0)
+}
+
+// OmahaLow makes the best low out of exactly two hole cards and three board cards.
+func OmahaLow(holeCards, board []card.Card) (Score, []card.Card, error) {
+	if len(holeCards) < 2 || len(board) < 3 {
+		return 0, nil, ErrInvalidCardCount{count: len(holeCards) + len(board)}
+	}
+	return bestLow(holeCards, board, 2)
+}
+
+// bestLow makes the best low out of exactly fromHole hole cards and the rest out of
+// the board, or out of any five hole cards if there is no board.
+func bestLow(holeCards, board []card.Card, fromHole int) (Score, []card.Card, error) {
+	if len(board) == 0 {
+		fromHole = 5
+	}
+	if len(holeCards) < fromHole || len(board) < 5-fromHole {
+		return 0, nil, ErrInvalidCardCount{count: len(holeCards) + len(board)}
+	}
+	var (
+		best     Score
+		bestHand []card.Card
+	)
+	for _, fromHand := range combinations(len(holeCards), fromHole) {
+		for _, fromBoard := range combinations(len(board), 5-fromHole) {
+			hand := make([]card.Card, 0, 5)
+			for _, i := range fromHand {
+				hand = append(hand, holeCards[i])
+			}
+			for _, i := range fromBoard {
+				hand = append(hand, board[i])
+			}
+			s, err := Low(hand)
+			if err != nil {
+				return 0, nil, err
+			}
+			if s > best {
+				best, bestHand = s, hand
+			}
+		}
+	}
+	return best, bestHand, nil
+}
+
+// lowValue is the value of the rank in a low hand, where the ace is one.
+func lowValue(r rank.Rank) int {
+	if r == rank.Ace {
+		return 1
+	}
+	return int(r - rank.Two + 2)
+}
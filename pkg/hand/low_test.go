@@ -0,0 +1,122 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+func TestLow(t *testing.T) {
+	wheel := []card.Card{
+		card.New(rank.Five, suit.Clubs),
+		card.New(rank.Four, suit.Clubs),
+		card.New(rank.Three, suit.Clubs),
+		card.New(rank.Two, suit.Clubs),
+		card.New(rank.Ace, suit.Clubs),
+	}
+	sevenLow := []card.Card{
+		card.New(rank.Seven, suit.Hearts),
+		card.New(rank.Five, suit.Spades),
+		card.New(rank.Four, suit.Diamonds),
+		card.New(rank.Three, suit.Clubs),
+		card.New(rank.Two, suit.Spades),
+	}
+	eightLow := []card.Card{
+		card.New(rank.Eight, suit.Hearts),
+		card.New(rank.Seven, suit.Spades),
+		card.New(rank.Six, suit.Diamonds),
+		card.New(rank.Five, suit.Clubs),
+		card.New(rank.Four, suit.Spades),
+	}
+	testCases := []struct {
+		name        string
+		better      []card.Card
+		worse       []card.Card
+		noQualifier bool
+	}{
+		{name: "WheelBeatsSeven", better: wheel, worse: sevenLow},
+		{name: "SevenBeatsEight", better: sevenLow, worse: eightLow},
+		{
+			name:   "PairNoLow",
+			better: eightLow,
+			worse: []card.Card{
+				card.New(rank.Ace, suit.Hearts),
+				card.New(rank.Ace, suit.Spades),
+				card.New(rank.Two, suit.Diamonds),
+				card.New(rank.Three, suit.Clubs),
+				card.New(rank.Four, suit.Spades),
+			},
+			noQualifier: true,
+		},
+		{
+			name:   "NineNoLow",
+			better: eightLow,
+			worse: []card.Card{
+				card.New(rank.Nine, suit.Hearts),
+				card.New(rank.Five, suit.Spades),
+				card.New(rank.Four, suit.Diamonds),
+				card.New(rank.Three, suit.Clubs),
+				card.New(rank.Two, suit.Spades),
+			},
+			noQualifier: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			better, err := Low(tc.better)
+			if err != nil {
+				t.Fatalf("Low(%v).err = %v", tc.better, err)
+			}
+			worse, err := Low(tc.worse)
+			if err != nil {
+				t.Fatalf("Low(%v).err = %v", tc.worse, err)
+			}
+			if better <= worse {
+				t.Errorf("Low(%v) = %d, want greater than Low(%v) = %d", tc.better, better, tc.worse, worse)
+			}
+			if tc.noQualifier && worse != 0 {
+				t.Errorf("Low(%v) = %d, want no low", tc.worse, worse)
+			}
+		})
+	}
+}
+
+func TestOmahaLow(t *testing.T) {
+	board := []card.Card{
+		card.New(rank.Two, suit.Clubs),
+		card.New(rank.Three, suit.Hearts),
+		card.New(rank.Four, suit.Diamonds),
+		card.New(rank.Five, suit.Spades),
+		card.New(rank.King, suit.Spades),
+	}
+	oneLowCard := []card.Card{
+		card.New(rank.Ace, suit.Hearts),
+		card.New(rank.King, suit.Diamonds),
+		card.New(rank.Queen, suit.Clubs),
+		card.New(rank.Jack, suit.Clubs),
+	}
+	if score, _, err := HoldemLow(oneLowCard, board); err != nil || score == 0 {
+		t.Errorf("HoldemLow(%v, %v) = %d, %v, want a low", oneLowCard, board, score, err)
+	}
+	if score, _, err := OmahaLow(oneLowCard, board); err != nil || score != 0 {
+		t.Errorf("OmahaLow(%v, %v) = %d, %v, want no low", oneLowCard, board, score, err)
+	}
+
+	twoLowCards := []card.Card{
+		card.New(rank.Ace, suit.Hearts),
+		card.New(rank.Eight, suit.Diamonds),
+		card.New(rank.Queen, suit.Clubs),
+		card.New(rank.Jack, suit.Clubs),
+	}
+	score, cards, err := OmahaLow(twoLowCards, board)
+	if err != nil {
+		t.Fatalf("OmahaLow(%v, %v).err = %v", twoLowCards, board, err)
+	}
+	want, _ := Low([]card.Card{twoLowCards[0], twoLowCards[1], board[0], board[1], board[2]})
+	if score != want {
+		t.Errorf("OmahaLow(%v, %v) = %d (%v), want %d", twoLowCards, board, score, cards, want)
+	}
+}
@@ -0,0 +1,160 @@
+package hand
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+)
+
+// Score ranks a five card hand, the higher score wins and equal scores split.
+// The strength of the hand value in the game takes the high bits, then the hand
+// value itself, followed by the ranks deciding between hands of the same value,
+// four bits each.
+type Score int
+
+const kickerBits = 4
+
+// Hand returns the value of the scored hand.
+func (s Score) Hand() Hand {
+	return Hand(s >> (5 * kickerBits) & (1<<kickerBits - 1))
+}
+
+type ErrInvalidCardCount struct {
+	count int
+}
+
+func (e ErrInvalidCardCount) Error() string {
+	return fmt.Sprintf("invalid card count: %d", e.count)
+}
+
+// Evaluate scores five cards.
+func Evaluate(cards []card.Card) (Score, error) {
+	value, err := Value(cards)
+	if err != nil {
+		return 0, err
+	}
+	if value == Invalid {
+		return 0, ErrUnknownHandValue{}
+	}
+	return score(value, value, kickers(value, cards)), nil
+}
+
+// EvaluateThree scores three cards, such as the top row in open-face Chinese, where
+// only high cards, pairs and three of a kind count. The score compares with the
+// scores of five cards.
+func EvaluateThree(cards []card.Card) (Score, error) {
+	if len(cards) != 3 {
+		return 0, ErrInvalidCardCount{count: len(cards)}
+	}
+	if existSameCards(cards) {
+		return 0, ErrExistSameCards{}
+	}
+	ranks := kickers(HighCard, cards)
+	value := HighCard
+	switch len(ranks) {
+	case 2:
+		value = Pair
+	case 1:
+		value = ThreeOfAKind
+	}
+	return score(value, value, ranks), nil
+}
+
+// Best scores the best five card hand out of at least five cards, it returns the
+// cards making the hand.
+func Best(cards []card.Card) (Score, []card.Card, error) {
+	return bestOf(cards, Evaluate)
+}
+
+func bestOf(cards []card.Card, evaluate func([]card.Card) (Score, error)) (Score, []card.Card, error) {
+	if existSameCards(cards) {
+		return 0, nil, ErrExistSameCards{}
+	}
+	return bestOfAny(cards, evaluate)
+}
+
+// bestOfAny is bestOf for cards which might come from several decks.
+func bestOfAny(cards []card.Card, evaluate func([]card.Card) (Score, error)) (Score, []card.Card, error) {
+	if len(cards) < 5 {
+		return 0, nil, ErrInvalidCardCount{count: len(cards)}
+	}
+	var (
+		best     Score
+		bestHand []card.Card
+	)
+	for _, combination := range combinations(len(cards), 5) {
+		hand := make([]card.Card, 0, 5)
+		for _, i := range combination {
+			hand = append(hand, cards[i])
+		}
+		s, err := evaluate(hand)
+		if err != nil {
+			return 0, nil, err
+		}
+		if s > best {
+			best, bestHand = s, hand
+		}
+	}
+	return best, bestHand, nil
+}
+
+func score(strength, value Hand, ranks []rank.Rank) Score {
+	s := Score(strength)<<kickerBits | Score(value)
+	for i := range 5 {
+		s <<= kickerBits
+		if i < len(ranks) {
+			s |= Score(ranks[i])
+		}
+	}
+	return s
+}
+
+// kickers returns the ranks deciding between hands of the same value: the ranks
+// grouped by count and then by rank, from the highest, or the top card of a straight.
+func kickers(value Hand, cards []card.Card) []rank.Rank {
+	counts := make(map[rank.Rank]int)
+	for _, c := range cards {
+		counts[c.Rank()]++
+	}
+	ranks := make([]rank.Rank, 0, len(counts))
+	for r := range counts {
+		ranks = append(ranks, r)
+	}
+	slices.SortFunc(ranks, func(a, b rank.Rank) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(b, a)
+	})
+	switch value {
+	case Straight, StraightFlush, RoyalFlush:
+		if ranks[0] == rank.Ace && ranks[1] == rank.Five {
+			// A 2 3 4 5, the ace plays low
+			return []rank.Rank{rank.Five}
+		}
+		return ranks[:1]
+	}
+	return ranks
+}
+
+// combinations returns every way to choose k indexes out of n, in lexicographic order.
+func combinations(n, k int) [][]int {
+	result := make([][]int, 0)
+	combination := make([]int, k)
+	var choose func(start, depth int)
+	choose = func(start, depth int) {
+		if depth == k {
+			result = append(result, slices.Clone(combination))
+			return
+		}
+		for i := start; i <= n-k+depth; i++ {
+			combination[depth] = i
+			choose(i+1, depth+1)
+		}
+	}
+	choose(0, 0)
+	return result
+}
@@ -0,0 +1,191 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+func TestEvaluateCompare(t *testing.T) {
+	testCases := []struct {
+		name      string
+		high, low []card.Card
+		split     bool
+		highHand  Hand
+		lowHand   Hand
+	}{
+		{
+			name: "PairKicker",
+			high: []card.Card{
+				card.New(rank.King, suit.Clubs),
+				card.New(rank.King, suit.Hearts),
+				card.New(rank.Ace, suit.Diamonds),
+				card.New(rank.Seven, suit.Clubs),
+				card.New(rank.Two, suit.Spades),
+			},
+			low: []card.Card{
+				card.New(rank.King, suit.Spades),
+				card.New(rank.King, suit.Diamonds),
+				card.New(rank.Queen, suit.Diamonds),
+				card.New(rank.Jack, suit.Clubs),
+				card.New(rank.Nine, suit.Spades),
+			},
+			highHand: Pair,
+			lowHand:  Pair,
+		},
+		{
+			name: "FullHouseTripsFirst",
+			high: []card.Card{
+				card.New(rank.Three, suit.Clubs),
+				card.New(rank.Three, suit.Hearts),
+				card.New(rank.Three, suit.Diamonds),
+				card.New(rank.Two, suit.Clubs),
+				card.New(rank.Two, suit.Spades),
+			},
+			low: []card.Card{
+				card.New(rank.Two, suit.Clubs),
+				card.New(rank.Two, suit.Hearts),
+				card.New(rank.Two, suit.Diamonds),
+				card.New(rank.Ace, suit.Clubs),
+				card.New(rank.Ace, suit.Spades),
+			},
+			highHand: FullHouse,
+			lowHand:  FullHouse,
+		},
+		{
+			name: "WheelLowestStraight",
+			high: []card.Card{
+				card.New(rank.Two, suit.Clubs),
+				card.New(rank.Three, suit.Hearts),
+				card.New(rank.Four, suit.Diamonds),
+				card.New(rank.Five, suit.Clubs),
+				card.New(rank.Six, suit.Spades),
+			},
+			low: []card.Card{
+				card.New(rank.Ace, suit.Hearts),
+				card.New(rank.Two, suit.Diamonds),
+				card.New(rank.Three, suit.Diamonds),
+				card.New(rank.Four, suit.Clubs),
+				card.New(rank.Five, suit.Spades),
+			},
+			highHand: Straight,
+			lowHand:  Straight,
+		},
+		{
+			name: "SplitOnSuits",
+			high: []card.Card{
+				card.New(rank.Ace, suit.Clubs),
+				card.New(rank.Ten, suit.Hearts),
+				card.New(rank.Eight, suit.Diamonds),
+				card.New(rank.Five, suit.Clubs),
+				card.New(rank.Three, suit.Spades),
+			},
+			low: []card.Card{
+				card.New(rank.Ace, suit.Hearts),
+				card.New(rank.Ten, suit.Diamonds),
+				card.New(rank.Eight, suit.Clubs),
+				card.New(rank.Five, suit.Spades),
+				card.New(rank.Three, suit.Hearts),
+			},
+			split:    true,
+			highHand: HighCard,
+			lowHand:  HighCard,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			high, err := Evaluate(tc.high)
+			if err != nil {
+				t.Fatalf("Evaluate(%v).err = %v", tc.high, err)
+			}
+			if high.Hand() != tc.highHand {
+				t.Errorf("Evaluate(%v).Hand() = %v, want %v", tc.high, high.Hand(), tc.highHand)
+			}
+			low, err := Evaluate(tc.low)
+			if err != nil {
+				t.Fatalf("Evaluate(%v).err = %v", tc.low, err)
+			}
+			if low.Hand() != tc.lowHand {
+				t.Errorf("Evaluate(%v).Hand() = %v, want %v", tc.low, low.Hand(), tc.lowHand)
+			}
+			if tc.split && high != low {
+				t.Errorf("score %d and %d, want equal", high, low)
+			}
+			if !tc.split && high <= low {
+				t.Errorf("score %d, want greater than %d", high, low)
+			}
+		})
+	}
+}
+
+func TestBest(t *testing.T) {
+	cards := []card.Card{
+		card.New(rank.Ace, suit.Hearts),
+		card.New(rank.King, suit.Hearts),
+		card.New(rank.Queen, suit.Hearts),
+		card.New(rank.Jack, suit.Hearts),
+		card.New(rank.Two, suit.Clubs),
+		card.New(rank.Ten, suit.Hearts),
+		card.New(rank.Ace, suit.Spades),
+	}
+	score, best, err := Best(cards)
+	if err != nil {
+		t.Fatalf("Best(%v).err = %v", cards, err)
+	}
+	if score.Hand() != RoyalFlush {
+		t.Errorf("Best(%v).Hand() = %v, want %v", cards, score.Hand(), RoyalFlush)
+	}
+	if len(best) != 5 {
+		t.Errorf("Best(%v) cards = %v, want five cards", cards, best)
+	}
+
+	if _, _, err := Best(cards[:4]); err != (ErrInvalidCardCount{count: 4}) {
+		t.Errorf("Best(%v).err = %v, want %v", cards[:4], err, ErrInvalidCardCount{count: 4})
+	}
+}
+
+func TestEvaluateThree(t *testing.T) {
+	trips := []card.Card{
+		card.New(rank.Two, suit.Clubs),
+		card.New(rank.Two, suit.Hearts),
+		card.New(rank.Two, suit.Spades),
+	}
+	pair := []card.Card{
+		card.New(rank.Ace, suit.Clubs),
+		card.New(rank.Ace, suit.Hearts),
+		card.New(rank.King, suit.Spades),
+	}
+	fivePair := []card.Card{
+		card.New(rank.Ace, suit.Diamonds),
+		card.New(rank.Ace, suit.Spades),
+		card.New(rank.King, suit.Diamonds),
+		card.New(rank.Three, suit.Clubs),
+		card.New(rank.Two, suit.Diamonds),
+	}
+
+	tripsScore, err := EvaluateThree(trips)
+	if err != nil {
+		t.Fatalf("EvaluateThree(%v).err = %v", trips, err)
+	}
+	if tripsScore.Hand() != ThreeOfAKind {
+		t.Errorf("EvaluateThree(%v).Hand() = %v, want %v", trips, tripsScore.Hand(), ThreeOfAKind)
+	}
+	pairScore, err := EvaluateThree(pair)
+	if err != nil {
+		t.Fatalf("EvaluateThree(%v).err = %v", pair, err)
+	}
+	if pairScore >= tripsScore {
+		t.Errorf("EvaluateThree(%v) = %d, want less than EvaluateThree(%v) = %d", pair, pairScore, trips, tripsScore)
+	}
+	// the same pair and kicker, the five card hand has more kickers
+	fivePairScore, err := Evaluate(fivePair)
+	if err != nil {
+		t.Fatalf("Evaluate(%v).err = %v", fivePair, err)
+	}
+	if pairScore >= fivePairScore {
+		t.Errorf("EvaluateThree(%v) = %d, want less than Evaluate(%v) = %d", pair, pairScore, fivePair, fivePairScore)
+	}
+}
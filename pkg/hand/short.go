@@ -0,0 +1,52 @@
+package hand
+
+import (
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+)
+
+// EvaluateShort scores five cards of a short deck, which has no card below six:
+// a flush beats a full house, as it is harder to make, and the ace plays low in
+// A-6-7-8-9, the lowest straight.
+func EvaluateShort(cards []card.Card) (Score, error) {
+	value, err := Value(cards)
+	if err != nil {
+		return 0, err
+	}
+	if value == Invalid {
+		return 0, ErrUnknownHandValue{}
+	}
+	ranks := kickers(value, cards)
+	if lowStraight(cards) {
+		flush := value == Flush
+		value, ranks = Straight, []rank.Rank{rank.Nine}
+		if flush {
+			value = StraightFlush
+		}
+	}
+	strength := value
+	switch value {
+	case Flush:
+		strength = FullHouse
+	case FullHouse:
+		strength = Flush
+	}
+	return score(strength, value, ranks), nil
+}
+
+// ShortDeck makes the best short deck hand out of any of the hole cards and the
+// board cards.
+func ShortDeck(holeCards, board []card.Card) (Score, []card.Card, error) {
+	cards := make([]card.Card, 0, len(holeCards)+len(board))
+	cards = append(append(cards, holeCards...), board...)
+	return bestOf(cards, EvaluateShort)
+}
+
+// lowStraight reports whether the cards are A-6-7-8-9.
+func lowStraight(cards []card.Card) bool {
+	ranks := make(map[rank.Rank]bool, len(cards))
+	for _, c := range cards {
+		ranks[c.Rank()] = true
+	}
+	return len(ranks) == 5 && ranks[rank.Ace] && ranks[rank.Six] && ranks[rank.Seven] && ranks[rank.Eight] && ranks[rank.Nine]
+}
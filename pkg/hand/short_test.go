@@ -0,0 +1,75 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+func TestEvaluateShort(t *testing.T) {
+	flush := []card.Card{
+		card.New(rank.Ace, suit.Hearts),
+		card.New(rank.Jack, suit.Hearts),
+		card.New(rank.Nine, suit.Hearts),
+		card.New(rank.Eight, suit.Hearts),
+		card.New(rank.Six, suit.Hearts),
+	}
+	fullHouse := []card.Card{
+		card.New(rank.Ace, suit.Clubs),
+		card.New(rank.Ace, suit.Spades),
+		card.New(rank.Ace, suit.Diamonds),
+		card.New(rank.King, suit.Clubs),
+		card.New(rank.King, suit.Spades),
+	}
+	lowStraight := []card.Card{
+		card.New(rank.Ace, suit.Clubs),
+		card.New(rank.Six, suit.Spades),
+		card.New(rank.Seven, suit.Diamonds),
+		card.New(rank.Eight, suit.Clubs),
+		card.New(rank.Nine, suit.Spades),
+	}
+	sixHighStraight := []card.Card{
+		card.New(rank.Six, suit.Clubs),
+		card.New(rank.Seven, suit.Spades),
+		card.New(rank.Eight, suit.Diamonds),
+		card.New(rank.Nine, suit.Clubs),
+		card.New(rank.Ten, suit.Spades),
+	}
+	aceHigh := []card.Card{
+		card.New(rank.Ace, suit.Clubs),
+		card.New(rank.King, suit.Spades),
+		card.New(rank.Queen, suit.Diamonds),
+		card.New(rank.Jack, suit.Clubs),
+		card.New(rank.Nine, suit.Spades),
+	}
+	testCases := []struct {
+		name          string
+		better, worse []card.Card
+		betterHand    Hand
+	}{
+		{"FlushBeatsFullHouse", flush, fullHouse, Flush},
+		{"SixHighBeatsLowStraight", sixHighStraight, lowStraight, Straight},
+		{"LowStraightBeatsAceHigh", lowStraight, aceHigh, Straight},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			better, err := EvaluateShort(tc.better)
+			if err != nil {
+				t.Fatalf("EvaluateShort(%v).err = %v", tc.better, err)
+			}
+			worse, err := EvaluateShort(tc.worse)
+			if err != nil {
+				t.Fatalf("EvaluateShort(%v).err = %v", tc.worse, err)
+			}
+			if better <= worse {
+				t.Errorf("EvaluateShort(%v) = %d, want greater than EvaluateShort(%v) = %d", tc.better, better, tc.worse, worse)
+			}
+			if better.Hand() != tc.betterHand {
+				t.Errorf("EvaluateShort(%v).Hand() = %v, want %v", tc.better, better.Hand(), tc.betterHand)
+			}
+		})
+	}
+}
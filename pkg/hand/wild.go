@@ -0,0 +1,75 @@
+package hand
+
+import (
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+// EvaluateWild scores five cards where the jokers and the cards of the wild ranks
+// stand for whichever card makes the best hand, and the cards might come from
+// several decks. Five of a kind is the best hand.
+func EvaluateWild(cards []card.Card, wild ...rank.Rank) (Score, error) {
+	if len(cards) != 5 {
+		return 0, ErrInvalidHandSize{}
+	}
+	hand := make([]card.Card, 0, 5)
+	for _, c := range cards {
+		if !c.IsJoker() && !slices.Contains(wild, c.Rank()) {
+			hand = append(hand, c)
+		}
+	}
+	naturals := len(hand)
+	hand = hand[:5]
+
+	// the wild cards take the suit of the first natural card, only a flush needs them
+	// to be of a suit, and a flush needs all the natural cards of the same suit
+	s := suit.Spades
+	if naturals > 0 {
+		s = hand[0].Suit()
+	}
+	var (
+		best       Score
+		substitute func(i int, lowest rank.Rank) error
+	)
+	// the wild cards are interchangeable, they stand for the ranks in ascending order
+	substitute = func(i int, lowest rank.Rank) error {
+		if i == len(hand) {
+			v, err := value(hand)
+			if err != nil {
+				return err
+			}
+			if got := score(v, v, kickers(v, hand)); got > best {
+				best = got
+			}
+			return nil
+		}
+		for r := lowest; r <= rank.Ace; r++ {
+			hand[i] = card.New(r, s)
+			if err := substitute(i+1, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := substitute(naturals, rank.Two); err != nil {
+		return 0, err
+	}
+	return best, nil
+}
+
+// Wild makes the best hand out of any of the hole cards and the board cards, where
+// the jokers and the cards of the given ranks are wild, and the cards might come
+// from several decks.
+func Wild(wild ...rank.Rank) Evaluator {
+	evaluate := func(cards []card.Card) (Score, error) {
+		return EvaluateWild(cards, wild...)
+	}
+	return func(holeCards, board []card.Card) (Score, []card.Card, error) {
+		cards := make([]card.Card, 0, len(holeCards)+len(board))
+		cards = append(append(cards, holeCards...), board...)
+		return bestOfAny(cards, evaluate)
+	}
+}
@@ -0,0 +1,113 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+func TestEvaluateWild(t *testing.T) {
+	testCases := []struct {
+		name  string
+		cards []card.Card
+		wild  []rank.Rank
+		want  Hand
+	}{
+		{
+			name: "JokersFiveOfAKind",
+			cards: []card.Card{
+				card.New(rank.Ace, suit.Clubs),
+				card.New(rank.Ace, suit.Hearts),
+				card.New(rank.Ace, suit.Spades),
+				card.NewJoker(),
+				card.NewJoker(),
+			},
+			want: FiveOfAKind,
+		},
+		{
+			name: "DeucesStraightFlush",
+			cards: []card.Card{
+				card.New(rank.Nine, suit.Hearts),
+				card.New(rank.Eight, suit.Hearts),
+				card.New(rank.Six, suit.Hearts),
+				card.New(rank.Two, suit.Clubs),
+				card.New(rank.Two, suit.Spades),
+			},
+			wild: []rank.Rank{rank.Two},
+			want: StraightFlush,
+		},
+		{
+			name: "JokerStraight",
+			cards: []card.Card{
+				card.New(rank.Nine, suit.Hearts),
+				card.New(rank.Eight, suit.Clubs),
+				card.New(rank.Seven, suit.Hearts),
+				card.New(rank.Five, suit.Diamonds),
+				card.NewJoker(),
+			},
+			want: Straight,
+		},
+		{
+			name: "DoubleDeckFullHouse",
+			cards: []card.Card{
+				card.New(rank.King, suit.Clubs),
+				card.New(rank.King, suit.Clubs),
+				card.New(rank.King, suit.Hearts),
+				card.New(rank.Four, suit.Spades),
+				card.New(rank.Four, suit.Spades),
+			},
+			want: FullHouse,
+		},
+		{
+			name: "AllWild",
+			cards: []card.Card{
+				card.NewJoker(),
+				card.New(rank.Two, suit.Clubs),
+				card.New(rank.Two, suit.Hearts),
+				card.New(rank.Two, suit.Spades),
+				card.New(rank.Two, suit.Diamonds),
+			},
+			wild: []rank.Rank{rank.Two},
+			want: FiveOfAKind,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			score, err := EvaluateWild(tc.cards, tc.wild...)
+			if err != nil {
+				t.Fatalf("EvaluateWild(%v, %v).err = %v", tc.cards, tc.wild, err)
+			}
+			if score.Hand() != tc.want {
+				t.Errorf("EvaluateWild(%v, %v).Hand() = %v, want %v", tc.cards, tc.wild, score.Hand(), tc.want)
+			}
+		})
+	}
+}
+
+func TestWild(t *testing.T) {
+	// two aces of spades from a double deck, and a joker
+	holeCards := []card.Card{
+		card.New(rank.Ace, suit.Spades),
+		card.New(rank.Ace, suit.Spades),
+	}
+	board := []card.Card{
+		card.NewJoker(),
+		card.New(rank.Ace, suit.Hearts),
+		card.New(rank.Ace, suit.Clubs),
+		card.New(rank.Seven, suit.Diamonds),
+		card.New(rank.Two, suit.Clubs),
+	}
+	if _, _, err := Holdem(holeCards, board); err != (ErrExistSameCards{}) {
+		t.Errorf("Holdem(%v, %v).err = %v, want %v", holeCards, board, err, ErrExistSameCards{})
+	}
+	score, _, err := Wild()(holeCards, board)
+	if err != nil {
+		t.Fatalf("Wild()(%v, %v).err = %v", holeCards, board, err)
+	}
+	if score.Hand() != FiveOfAKind {
+		t.Errorf("Wild()(%v, %v).Hand() = %v, want %v", holeCards, board, score.Hand(), FiveOfAKind)
+	}
+}
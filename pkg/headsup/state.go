@@ -0,0 +1,32 @@
+package headsup
+
+import (
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/game"
+	"github.com/yshngg/holdem/pkg/variant"
+)
+
+// Button is the seat of the button, the other seat is the big blind.
+const Button = 0
+
+// Config is the stakes and the stacks of a heads-up hand of no-limit hold'em.
+type Config struct {
+	SmallBlind, BigBlind int
+
+	// Stacks are the chips of the button and of the big blind at the start of the hand.
+	Stacks [2]int
+}
+
+// New deals a heads-up hand of no-limit hold'em from the deck, in order, the big
+// blind first. The button posts the small blind and acts first before the flop,
+// and last after it.
+func New(config Config, deck []card.Card) (game.State, error) {
+	return game.New(game.Config{
+		Variant:    variant.Holdem,
+		Limit:      variant.NoLimit,
+		SmallBlind: config.SmallBlind,
+		BigBlind:   config.BigBlind,
+		Button:     Button,
+		Stacks:     config.Stacks[:],
+	}, deck)
+}
@@ -0,0 +1,174 @@
+package headsup
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/game"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+// aces deals pocket aces to the button and a seven-deuce to the big blind, on a
+// board of no help to the big blind.
+var aces = []card.Card{
+	card.New(rank.Seven, suit.Clubs),  // big blind
+	card.New(rank.Ace, suit.Clubs),    // button
+	card.New(rank.Two, suit.Hearts),   // big blind
+	card.New(rank.Ace, suit.Hearts),   // button
+	card.New(rank.Three, suit.Spades), // burn
+	card.New(rank.King, suit.Spades),
+	card.New(rank.Queen, suit.Diamonds),
+	card.New(rank.Nine, suit.Clubs),
+	card.New(rank.Four, suit.Spades), // burn
+	card.New(rank.Jack, suit.Diamonds),
+	card.New(rank.Five, suit.Spades), // burn
+	card.New(rank.Ten, suit.Hearts),
+}
+
+var config = Config{SmallBlind: 1, BigBlind: 2, Stacks: [2]int{100, 100}}
+
+func apply(t *testing.T, s game.State, actions ...player.Action) game.State {
+	t.Helper()
+	for _, action := range actions {
+		next, err := s.Apply(action)
+		if err != nil {
+			t.Fatalf("Apply(%v).err = %v", action, err)
+		}
+		s = next
+	}
+	return s
+}
+
+func TestBlinds(t *testing.T) {
+	s, err := New(config, aces)
+	if err != nil {
+		t.Fatalf("New().err = %v", err)
+	}
+	if s.ToAct() != Button {
+		t.Errorf("ToAct() = %d before the flop, want the button %d", s.ToAct(), Button)
+	}
+	if !slices.Equal(s.Stacks(), []int{99, 98}) {
+		t.Errorf("Stacks() = %v, want the button to post the small blind", s.Stacks())
+	}
+
+	// the big blind has the option after the button limps
+	s = apply(t, s, player.Action{Type: player.ActionCall})
+	if s.ToAct() != 1-Button || s.Street() != game.PreFlop {
+		t.Errorf("ToAct() = %d on %s, want the big blind before the flop", s.ToAct(), s.Street())
+	}
+	s = apply(t, s, player.Action{Type: player.ActionCheck})
+	if s.Street() != game.Flop || len(s.Board()) != 3 {
+		t.Fatalf("Street() = %s with %v, want the flop", s.Street(), s.Board())
+	}
+	if s.ToAct() != 1-Button {
+		t.Errorf("ToAct() = %d after the flop, want the big blind %d", s.ToAct(), 1-Button)
+	}
+}
+
+func TestLegalActions(t *testing.T) {
+	s, err := New(config, aces)
+	if err != nil {
+		t.Fatalf("New().err = %v", err)
+	}
+	testCases := []struct {
+		name  string
+		state game.State
+		want  []player.Action
+	}{
+		{
+			name:  "SmallBlind",
+			state: s,
+			want: []player.Action{
+				{Type: player.ActionFold},
+				{Type: player.ActionCall, Chips: 1},
+				{Type: player.ActionRaise, Chips: 3},
+				{Type: player.ActionAllIn, Chips: 99},
+			},
+		},
+		{
+			name:  "FacingAllIn",
+			state: apply(t, s, player.Action{Type: player.ActionAllIn}),
+			want: []player.Action{
+				{Type: player.ActionFold},
+				{Type: player.ActionCall, Chips: 98},
+			},
+		},
+		{
+			name:  "Flop",
+			state: apply(t, s, player.Action{Type: player.ActionCall}, player.Action{Type: player.ActionCheck}),
+			want: []player.Action{
+				{Type: player.ActionCheck},
+				{Type: player.ActionBet, Chips: 2},
+				{Type: player.ActionAllIn, Chips: 98},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.state.LegalActions()
+			if len(got) != len(tc.want) {
+				t.Fatalf("LegalActions() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i].Type != tc.want[i].Type || got[i].Chips != tc.want[i].Chips {
+					t.Errorf("LegalActions()[%d] = %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	s, err := New(config, aces)
+	if err != nil {
+		t.Fatalf("New().err = %v", err)
+	}
+
+	raised := apply(t, s, player.Action{Type: player.ActionRaise, Chips: 5})
+	if !slices.Equal(s.Stacks(), []int{99, 98}) || len(s.History()) != 0 {
+		t.Errorf("Apply() changed the state: %v, %v", s.Stacks(), s.History())
+	}
+	if raised.Pot() != 8 {
+		t.Errorf("Pot() = %d, want 8", raised.Pot())
+	}
+	if _, err := raised.Apply(player.Action{Type: player.ActionRaise, Chips: 4}); !errors.As(err, &game.ErrIllegalAction{}) {
+		t.Errorf("Apply() under the least raise, err = %v, want an illegal action", err)
+	}
+
+	folded := apply(t, raised, player.Action{Type: player.ActionFold})
+	payoffs, err := folded.Payoffs()
+	if err != nil {
+		t.Fatalf("Payoffs().err = %v", err)
+	}
+	if !slices.Equal(payoffs, []int{2, -2}) {
+		t.Errorf("Payoffs() = %v, want the big blind to lose the blind", payoffs)
+	}
+	if _, err := folded.Apply(player.Action{Type: player.ActionCheck}); err != (game.ErrTerminal{}) {
+		t.Errorf("Apply() after the hand, err = %v, want %v", err, game.ErrTerminal{})
+	}
+}
+
+func TestShowdown(t *testing.T) {
+	short := Config{SmallBlind: 1, BigBlind: 2, Stacks: [2]int{100, 40}}
+	s, err := New(short, aces)
+	if err != nil {
+		t.Fatalf("New().err = %v", err)
+	}
+	s = apply(t, s, player.Action{Type: player.ActionAllIn}, player.Action{Type: player.ActionAllIn})
+	if !s.IsTerminal() || len(s.Board()) != 5 {
+		t.Fatalf("IsTerminal() = %v with %v, want the board run out", s.IsTerminal(), s.Board())
+	}
+	payoffs, err := s.Payoffs()
+	if err != nil {
+		t.Fatalf("Payoffs().err = %v", err)
+	}
+	// the uncalled chips go back to the button
+	if !slices.Equal(payoffs, []int{40, -40}) {
+		t.Errorf("Payoffs() = %v, want %v", payoffs, []int{40, -40})
+	}
+}
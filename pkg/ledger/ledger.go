@@ -0,0 +1,169 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Account holds chips in the ledger.
+type Account string
+
+const (
+	// Cashier issues the chips bought and takes back the chips cashed out,
+	// its balance is the negative of the chips in play.
+	Cashier Account = "cashier"
+
+	// Pot holds the chips bet in the hand until they are awarded.
+	Pot Account = "pot"
+
+	// House holds the rake.
+	House Account = "house"
+
+	// Insurer pays the all-in players who cash out their equity, and collects what
+	// they would have won instead. It can go below zero, as the cashier.
+	Insurer Account = "insurer"
+)
+
+// PlayerAccount returns the account of the player's stack.
+func PlayerAccount(id string) Account {
+	return Account("player:" + id)
+}
+
+// Transaction moves chips from an account to another. Every transaction debits
+// and credits the same amount, so the balances of all accounts always sum to zero.
+type Transaction struct {
+	// Sequence is the position of the transaction in the ledger, starting from 1.
+	Sequence int
+	Kind     Kind
+	From     Account
+	To       Account
+	Chips    int
+	Time     time.Time
+}
+
+type ErrInvalidChips struct {
+	chips int
+}
+
+func (e ErrInvalidChips) Error() string {
+	return fmt.Sprintf("invalid chips: %d", e.chips)
+}
+
+type ErrInsufficientBalance struct {
+	account        Account
+	balance, chips int
+}
+
+func (e ErrInsufficientBalance) Error() string {
+	return fmt.Sprintf("insufficient balance of %s, have: %d, want: %d", e.account, e.balance, e.chips)
+}
+
+// Ledger is a double-entry chip ledger.
+type Ledger struct {
+	mu           sync.Mutex
+	transactions []Transaction
+	balances     map[Account]int
+}
+
+func New() *Ledger {
+	return &Ledger{
+		transactions: make([]Transaction, 0),
+		balances:     make(map[Account]int),
+	}
+}
+
+// Transfer records chips moved from an account to another. Only the cashier and
+// the insurer can go below zero.
+func (l *Ledger) Transfer(kind Kind, from, to Account, chips int) (Transaction, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.check(from, chips); err != nil {
+		return Transaction{}, err
+	}
+	return l.transfer(kind, from, to, chips), nil
+}
+
+func (l *Ledger) check(from Account, chips int) error {
+	if chips <= 0 {
+		return ErrInvalidChips{chips: chips}
+	}
+	if from != Cashier && from != Insurer && l.balances[from] < chips {
+		return ErrInsufficientBalance{account: from, balance: l.balances[from], chips: chips}
+	}
+	return nil
+}
+
+func (l *Ledger) transfer(kind Kind, from, to Account, chips int) Transaction {
+	transaction := Transaction{
+		Sequence: len(l.transactions) + 1,
+		Kind:     kind,
+		From:     from,
+		To:       to,
+		Chips:    chips,
+		Time:     time.Now(),
+	}
+	l.transactions = append(l.transactions, transaction)
+	l.balances[from] -= chips
+	l.balances[to] += chips
+	return transaction
+}
+
+// BuyIn debits the cost from the player's wallet and credits the chips to the stack,
+// either both or neither. A nil wallet only credits the chips.
+func (l *Ledger) BuyIn(ctx context.Context, wallet Wallet, id string, kind Kind, chips, cost int) (Transaction, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.check(Cashier, chips); err != nil {
+		return Transaction{}, err
+	}
+	if wallet != nil && cost > 0 {
+		if err := wallet.Debit(ctx, id, cost); err != nil {
+			return Transaction{}, fmt.Errorf("debit wallet (id: %s), err: %w", id, err)
+		}
+	}
+	return l.transfer(kind, Cashier, PlayerAccount(id), chips), nil
+}
+
+// CashOut debits the chips from the stack and credits them to the player's wallet,
+// either both or neither. A nil wallet only debits the chips.
+func (l *Ledger) CashOut(ctx context.Context, wallet Wallet, id string, chips int) (Transaction, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	account := PlayerAccount(id)
+	if err := l.check(account, chips); err != nil {
+		return Transaction{}, err
+	}
+	if wallet != nil {
+		if err := wallet.Credit(ctx, id, chips); err != nil {
+			return Transaction{}, fmt.Errorf("credit wallet (id: %s), err: %w", id, err)
+		}
+	}
+	return l.transfer(CashOut, account, Cashier, chips), nil
+}
+
+func (l *Ledger) Balance(account Account) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.balances[account]
+}
+
+// Transactions returns the transactions in order.
+func (l *Ledger) Transactions() []Transaction {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.transactions)
+}
+
+// Balanced reports whether the balances of all accounts sum to zero.
+func (l *Ledger) Balanced() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	sum := 0
+	for _, balance := range l.balances {
+		sum += balance
+	}
+	return sum == 0
+}
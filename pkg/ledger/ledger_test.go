@@ -0,0 +1,102 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestLedgerHand(t *testing.T) {
+	ctx := context.Background()
+	wallet := NewMemoryWallet(map[string]int{"a": 500, "b": 500})
+	l := New()
+
+	if _, err := l.BuyIn(ctx, wallet, "a", BuyIn, 200, 200); err != nil {
+		t.Fatalf("buy in a, err: %v", err)
+	}
+	if _, err := l.BuyIn(ctx, wallet, "b", BuyIn, 300, 300); err != nil {
+		t.Fatalf("buy in b, err: %v", err)
+	}
+	transfers := []struct {
+		kind     Kind
+		from, to Account
+		chips    int
+	}{
+		{Blind, PlayerAccount("a"), Pot, 1},
+		{Blind, PlayerAccount("b"), Pot, 2},
+		{Bet, PlayerAccount("a"), Pot, 99},
+		{Bet, PlayerAccount("b"), Pot, 98},
+		{Rake, Pot, House, 5},
+		{Award, Pot, PlayerAccount("b"), 195},
+	}
+	for _, tr := range transfers {
+		if _, err := l.Transfer(tr.kind, tr.from, tr.to, tr.chips); err != nil {
+			t.Fatalf("transfer %s, err: %v", tr.kind, err)
+		}
+	}
+	if _, err := l.CashOut(ctx, wallet, "b", l.Balance(PlayerAccount("b"))); err != nil {
+		t.Fatalf("cash out b, err: %v", err)
+	}
+
+	balances := map[Account]int{
+		PlayerAccount("a"): 100,
+		PlayerAccount("b"): 0,
+		Pot:                0,
+		House:              5,
+		Cashier:            -105,
+	}
+	for account, want := range balances {
+		if got := l.Balance(account); got != want {
+			t.Errorf("balance of %s: %d, want: %d", account, got, want)
+		}
+	}
+	if !l.Balanced() {
+		t.Errorf("ledger is not balanced")
+	}
+	if got := len(l.Transactions()); got != 9 {
+		t.Errorf("transactions: %d, want: %d", got, 9)
+	}
+	if got, _ := wallet.Balance(ctx, "b"); got != 595 {
+		t.Errorf("wallet of b: %d, want: %d", got, 595)
+	}
+}
+
+func TestLedgerAtomic(t *testing.T) {
+	ctx := context.Background()
+	wallet := NewMemoryWallet(map[string]int{"a": 100})
+	l := New()
+
+	_, err := l.BuyIn(ctx, wallet, "a", BuyIn, 200, 200)
+	if !errors.As(err, &ErrInsufficientFunds{}) {
+		t.Errorf("err: %v, want: %v", err, ErrInsufficientFunds{})
+	}
+	if got := l.Balance(PlayerAccount("a")); got != 0 {
+		t.Errorf("balance: %d, want: %d", got, 0)
+	}
+
+	_, err = l.CashOut(ctx, wallet, "a", 10)
+	if !errors.As(err, &ErrInsufficientBalance{}) {
+		t.Errorf("err: %v, want: %v", err, ErrInsufficientBalance{})
+	}
+	if got, _ := wallet.Balance(ctx, "a"); got != 100 {
+		t.Errorf("wallet: %d, want: %d", got, 100)
+	}
+
+	_, err = l.Transfer(Bet, PlayerAccount("a"), Pot, 10)
+	if !errors.As(err, &ErrInsufficientBalance{}) {
+		t.Errorf("err: %v, want: %v", err, ErrInsufficientBalance{})
+	}
+}
+
+func TestLedgerInsurance(t *testing.T) {
+	l := New()
+	if _, err := l.Transfer(Insurance, Insurer, PlayerAccount("a"), 80); err != nil {
+		t.Fatalf("pay insurance, err: %v", err)
+	}
+	if _, err := l.Transfer(Insurance, PlayerAccount("a"), Insurer, 100); !errors.As(err, &ErrInsufficientBalance{}) {
+		t.Errorf("err: %v, want: %v", err, ErrInsufficientBalance{})
+	}
+	if got := l.Balance(Insurer); got != -80 {
+		t.Errorf("balance of %s: %d, want: %d", Insurer, got, -80)
+	}
+}
@@ -0,0 +1,103 @@
+package ledger
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Kind is the kind of a chip purchase or transaction.
+type Kind string
+
+const (
+	BuyIn   Kind = "BuyIn"
+	Rebuy   Kind = "Rebuy"
+	TopUp   Kind = "TopUp"
+	AddOn   Kind = "AddOn"
+	ReEntry Kind = "ReEntry"
+	Ante    Kind = "Ante"
+	Blind   Kind = "Blind"
+	Bet     Kind = "Bet"
+	Award   Kind = "Award"
+	Rake    Kind = "Rake"
+	CashOut Kind = "CashOut"
+
+	Insurance Kind = "Insurance"
+)
+
+// Purchase is chips bought by a player.
+type Purchase struct {
+	ID    string
+	Kind  Kind
+	Chips int
+
+	// Cost is what the player paid for the chips.
+	Cost int
+	Time time.Time
+}
+
+// Purchases records every chip purchase, in order.
+type Purchases struct {
+	mu      sync.Mutex
+	records []Purchase
+}
+
+func NewPurchases() *Purchases {
+	return &Purchases{
+		records: make([]Purchase, 0),
+	}
+}
+
+func (p *Purchases) Record(id string, kind Kind, chips, cost int) Purchase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	purchase := Purchase{
+		ID:    id,
+		Kind:  kind,
+		Chips: chips,
+		Cost:  cost,
+		Time:  time.Now(),
+	}
+	p.records = append(p.records, purchase)
+	return purchase
+}
+
+// Records returns the purchases of the player, all of them if id is empty.
+func (p *Purchases) Records(id string) []Purchase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if id == "" {
+		return slices.Clone(p.records)
+	}
+	records := make([]Purchase, 0)
+	for _, purchase := range p.records {
+		if purchase.ID == id {
+			records = append(records, purchase)
+		}
+	}
+	return records
+}
+
+// Count returns how many purchases of the kind the player has made.
+func (p *Purchases) Count(id string, kind Kind) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	count := 0
+	for _, purchase := range p.records {
+		if purchase.ID == id && purchase.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Total returns the chips bought and the amount paid by everyone.
+func (p *Purchases) Total() (chips, cost int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, purchase := range p.records {
+		chips += purchase.Chips
+		cost += purchase.Cost
+	}
+	return chips, cost
+}
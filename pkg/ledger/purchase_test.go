@@ -0,0 +1,28 @@
+package ledger
+
+import "testing"
+
+func TestPurchases(t *testing.T) {
+	purchases := NewPurchases()
+	purchases.Record("a", BuyIn, 1000, 10)
+	purchases.Record("b", BuyIn, 1000, 10)
+	purchases.Record("a", Rebuy, 1000, 10)
+	purchases.Record("a", AddOn, 2000, 10)
+
+	if got := len(purchases.Records("")); got != 4 {
+		t.Errorf("records: %d, want: %d", got, 4)
+	}
+	if got := len(purchases.Records("a")); got != 3 {
+		t.Errorf("records of a: %d, want: %d", got, 3)
+	}
+	if got := purchases.Count("a", Rebuy); got != 1 {
+		t.Errorf("rebuys of a: %d, want: %d", got, 1)
+	}
+	if got := purchases.Count("b", Rebuy); got != 0 {
+		t.Errorf("rebuys of b: %d, want: %d", got, 0)
+	}
+	chips, cost := purchases.Total()
+	if chips != 5000 || cost != 40 {
+		t.Errorf("total: (%d, %d), want: (%d, %d)", chips, cost, 5000, 40)
+	}
+}
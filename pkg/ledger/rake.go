@@ -0,0 +1,65 @@
+package ledger
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// HandRake is the rake taken from a hand.
+type HandRake struct {
+	// Hand is the number of the hand at the table.
+	Hand int
+
+	// Pots are the rake taken from every pot, from the main pot.
+	Pots []int
+
+	Time time.Time
+}
+
+func (r HandRake) Total() int {
+	total := 0
+	for _, rake := range r.Pots {
+		total += rake
+	}
+	return total
+}
+
+// Rakes records the rake taken from every hand, in order.
+type Rakes struct {
+	mu      sync.Mutex
+	records []HandRake
+	total   int
+}
+
+func NewRakes() *Rakes {
+	return &Rakes{
+		records: make([]HandRake, 0),
+	}
+}
+
+func (r *Rakes) Record(hand int, pots []int) HandRake {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rake := HandRake{
+		Hand: hand,
+		Pots: slices.Clone(pots),
+		Time: time.Now(),
+	}
+	r.records = append(r.records, rake)
+	r.total += rake.Total()
+	return rake
+}
+
+func (r *Rakes) Records() []HandRake {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.records)
+}
+
+// Total returns the rake taken from all hands.
+func (r *Rakes) Total() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.total
+}
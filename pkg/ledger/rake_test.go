@@ -0,0 +1,20 @@
+package ledger
+
+import "testing"
+
+func TestRakes(t *testing.T) {
+	rakes := NewRakes()
+	rakes.Record(0, []int{3})
+	rakes.Record(1, []int{})
+	rake := rakes.Record(2, []int{4, 1})
+
+	if rake.Total() != 5 {
+		t.Errorf("rake: %d, want: %d", rake.Total(), 5)
+	}
+	if got := len(rakes.Records()); got != 3 {
+		t.Errorf("records: %d, want: %d", got, 3)
+	}
+	if got := rakes.Total(); got != 8 {
+		t.Errorf("total: %d, want: %d", got, 8)
+	}
+}
@@ -0,0 +1,92 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Wallet is an external bankroll the players buy chips with and cash out to.
+type Wallet interface {
+	// Debit takes the amount from the player's account, failing if it is short.
+	Debit(ctx context.Context, id string, amount int) error
+
+	// Credit adds the amount to the player's account.
+	Credit(ctx context.Context, id string, amount int) error
+
+	Balance(ctx context.Context, id string) (int, error)
+}
+
+type ErrInsufficientFunds struct {
+	id              string
+	balance, amount int
+}
+
+func (e ErrInsufficientFunds) Error() string {
+	return fmt.Sprintf("insufficient funds of player (id: %s), have: %d, want: %d", e.id, e.balance, e.amount)
+}
+
+type ErrInvalidAmount struct {
+	amount int
+}
+
+func (e ErrInvalidAmount) Error() string {
+	return fmt.Sprintf("invalid amount: %d", e.amount)
+}
+
+// MemoryWallet is a Wallet kept in memory, for tests and play money.
+type MemoryWallet struct {
+	mu       sync.Mutex
+	balances map[string]int
+}
+
+var _ Wallet = &MemoryWallet{}
+
+// NewMemoryWallet creates a wallet with the initial balances by player.
+func NewMemoryWallet(balances map[string]int) *MemoryWallet {
+	w := &MemoryWallet{
+		balances: make(map[string]int, len(balances)),
+	}
+	for id, balance := range balances {
+		w.balances[id] = balance
+	}
+	return w
+}
+
+func (w *MemoryWallet) Debit(ctx context.Context, id string, amount int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if amount < 0 {
+		return ErrInvalidAmount{amount: amount}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.balances[id] < amount {
+		return ErrInsufficientFunds{id: id, balance: w.balances[id], amount: amount}
+	}
+	w.balances[id] -= amount
+	return nil
+}
+
+func (w *MemoryWallet) Credit(ctx context.Context, id string, amount int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if amount < 0 {
+		return ErrInvalidAmount{amount: amount}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.balances[id] += amount
+	return nil
+}
+
+func (w *MemoryWallet) Balance(ctx context.Context, id string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.balances[id], nil
+}
@@ -0,0 +1,66 @@
+package lobby
+
+import (
+	"time"
+
+	"github.com/yshngg/holdem/pkg/watch"
+)
+
+type EventAction string
+
+const (
+	EventKind string = "lobby"
+
+	EventAddTable      EventAction = "AddTable"
+	EventRemoveTable   EventAction = "RemoveTable"
+	EventJoinWaitlist  EventAction = "JoinWaitlist"
+	EventLeaveWaitlist EventAction = "LeaveWaitlist"
+	EventWaitlistMove  EventAction = "WaitlistMove"
+	EventSeat          EventAction = "Seat"
+	EventSeatFailed    EventAction = "SeatFailed"
+)
+
+type EventObject struct {
+	// ID is the player the event is about, if any.
+	ID string
+
+	// Table is the table the event is about, empty for a stakes waitlist.
+	Table string
+
+	Stakes Stakes
+
+	// Position is the position of the player in the waitlist, starting from 1.
+	Position int
+}
+
+type Event struct {
+	action    EventAction
+	object    EventObject
+	eventTime time.Time
+}
+
+func NewEvent(action EventAction, object EventObject) watch.Event {
+	return Event{
+		action:    action,
+		object:    object,
+		eventTime: time.Now(),
+	}
+}
+
+func (e Event) Kind() string {
+	return EventKind
+}
+
+func (e Event) Action() string {
+	return string(e.action)
+}
+
+func (e Event) Related() any {
+	return e.object
+}
+
+func (e Event) Time() time.Time {
+	return e.eventTime
+}
+
+var _ watch.Event = Event{}
@@ -0,0 +1,358 @@
+package lobby
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/yshngg/holdem/pkg/table"
+	"github.com/yshngg/holdem/pkg/watch"
+	"k8s.io/klog/v2"
+)
+
+const defaultQueueLength = 64
+
+// Stakes are the blinds of a table.
+type Stakes struct {
+	SmallBlind int
+	BigBlind   int
+}
+
+// TableInfo is a table listed in the lobby.
+type TableInfo struct {
+	ID       string
+	Stakes   Stakes
+	Capacity int
+	Players  int
+
+	// Waiting is the number of players in the waitlist of the table.
+	Waiting int
+
+	AveragePot     float64
+	PlayersPerFlop float64
+}
+
+// Lobby lists the tables, and seats the players waiting for them as seats open.
+type Lobby struct {
+	mu sync.Mutex
+
+	tables map[string]*table.Table
+	// order is the IDs of the tables in the order they were added.
+	order []string
+	// watchers are the events of the tables, their seats are filled as they open.
+	watchers map[string]watch.Interface
+
+	// waitlists are the players waiting for a specific table, by table.
+	waitlists map[string]*waitlist
+	// stakesWaitlists are the players waiting for any table of the stakes.
+	stakesWaitlists map[Stakes]*waitlist
+
+	broadcaster watch.Broadcaster
+}
+
+func New() *Lobby {
+	return &Lobby{
+		tables:          make(map[string]*table.Table),
+		order:           make([]string, 0),
+		watchers:        make(map[string]watch.Interface),
+		waitlists:       make(map[string]*waitlist),
+		stakesWaitlists: make(map[Stakes]*waitlist),
+		broadcaster:     watch.NewBroadcaster(defaultQueueLength, defaultQueueLength),
+	}
+}
+
+type ErrTableExists struct {
+	id string
+}
+
+func (e ErrTableExists) Error() string {
+	return fmt.Sprintf("table (id: %s) exists", e.id)
+}
+
+type ErrTableNotFound struct {
+	id string
+}
+
+func (e ErrTableNotFound) Error() string {
+	return fmt.Sprintf("table (id: %s) not found", e.id)
+}
+
+func (l *Lobby) Watch() (watch.Interface, error) {
+	return l.broadcaster.Watch()
+}
+
+func (l *Lobby) Add(ctx context.Context, id string, t *table.Table) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, exists := l.tables[id]; exists {
+		return ErrTableExists{id: id}
+	}
+	w, err := t.Watch()
+	if err != nil {
+		return fmt.Errorf("watch table (id: %s), err: %w", id, err)
+	}
+	l.tables[id] = t
+	l.order = append(l.order, id)
+	l.waitlists[id] = &waitlist{}
+	l.watchers[id] = w
+	go l.watchTable(id, w)
+	if err := l.broadcast(EventAddTable, EventObject{Table: id, Stakes: stakesOf(t)}); err != nil {
+		return err
+	}
+	return l.fill(ctx, id)
+}
+
+// Remove removes the table from the lobby, the players waiting for it leave the waitlist.
+func (l *Lobby) Remove(id string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, exists := l.tables[id]
+	if !exists {
+		return ErrTableNotFound{id: id}
+	}
+	for _, waiter := range *l.waitlists[id] {
+		object := EventObject{ID: waiter.ID, Table: id, Stakes: stakesOf(t)}
+		if err := l.broadcast(EventLeaveWaitlist, object); err != nil {
+			return err
+		}
+	}
+	l.watchers[id].Stop()
+	delete(l.tables, id)
+	delete(l.waitlists, id)
+	delete(l.watchers, id)
+	l.order = slices.DeleteFunc(l.order, func(tid string) bool {
+		return tid == id
+	})
+	return l.broadcast(EventRemoveTable, EventObject{Table: id, Stakes: stakesOf(t)})
+}
+
+func (l *Lobby) Table(id string) (*table.Table, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, exists := l.tables[id]
+	return t, exists
+}
+
+// Tables lists the tables in the order they were added.
+func (l *Lobby) Tables() []TableInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	infos := make([]TableInfo, 0, len(l.order))
+	for _, id := range l.order {
+		t := l.tables[id]
+		stats := t.Stats()
+		infos = append(infos, TableInfo{
+			ID:             id,
+			Stakes:         stakesOf(t),
+			Capacity:       t.Capacity(),
+			Players:        t.PlayerCount(),
+			Waiting:        len(*l.waitlists[id]),
+			AveragePot:     stats.AveragePot(),
+			PlayersPerFlop: stats.PlayersPerFlop(),
+		})
+	}
+	return infos
+}
+
+// JoinWaitlist queues the player for the table, and seats the player at once if
+// a seat is open. It returns the position of the player in the waitlist.
+func (l *Lobby) JoinWaitlist(ctx context.Context, id string, waiter Waiter) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	list, exists := l.waitlists[id]
+	if !exists {
+		return 0, ErrTableNotFound{id: id}
+	}
+	position, added := list.add(waiter)
+	if added {
+		object := EventObject{ID: waiter.ID, Table: id, Stakes: stakesOf(l.tables[id]), Position: position}
+		if err := l.broadcast(EventJoinWaitlist, object); err != nil {
+			return 0, err
+		}
+	}
+	return position, l.fill(ctx, id)
+}
+
+// JoinStakesWaitlist queues the player for the first seat open at any table of the
+// stakes. It returns the position of the player in the waitlist.
+func (l *Lobby) JoinStakesWaitlist(ctx context.Context, stakes Stakes, waiter Waiter) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	list, exists := l.stakesWaitlists[stakes]
+	if !exists {
+		list = &waitlist{}
+		l.stakesWaitlists[stakes] = list
+	}
+	position, added := list.add(waiter)
+	if added {
+		object := EventObject{ID: waiter.ID, Stakes: stakes, Position: position}
+		if err := l.broadcast(EventJoinWaitlist, object); err != nil {
+			return 0, err
+		}
+	}
+	for _, tid := range l.order {
+		if stakesOf(l.tables[tid]) != stakes {
+			continue
+		}
+		if err := l.fill(ctx, tid); err != nil {
+			return 0, err
+		}
+	}
+	return list.position(waiter.ID), nil
+}
+
+// LeaveWaitlists removes the player from every waitlist.
+func (l *Lobby) LeaveWaitlists(id string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dequeue(id, EventLeaveWaitlist)
+}
+
+// Leave makes the player leave the table. The next player waiting is seated once the
+// seat is open, after the hand if one is played.
+func (l *Lobby) Leave(ctx context.Context, tableID, id string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, exists := l.tables[tableID]
+	if !exists {
+		return ErrTableNotFound{id: tableID}
+	}
+	if err := t.Leave(ctx, id); err != nil {
+		return fmt.Errorf("leave table (id: %s), err: %w", tableID, err)
+	}
+	return l.fill(ctx, tableID)
+}
+
+// Fill seats the players waiting at the tables with open seats, such as seats of
+// players removed by the tables.
+func (l *Lobby) Fill(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, id := range l.order {
+		if err := l.fill(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watchTable fills the seats of the table as the players who have left are released,
+// once the hand they left ends.
+func (l *Lobby) watchTable(id string, w watch.Interface) {
+	for event := range w.Watch() {
+		if event.Kind() != table.EventKind || event.Action() != string(table.EventSeatOpen) {
+			continue
+		}
+		// the table is not held up, the seat is filled once the hand is over, for no
+		// caller in particular
+		go func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			if _, exists := l.tables[id]; !exists {
+				return
+			}
+			if err := l.fill(context.Background(), id); err != nil {
+				klog.ErrorS(err, "fill table", "id", id)
+			}
+		}()
+	}
+}
+
+// fill seats the players waiting for the table, then the players waiting for its
+// stakes, until the table is full. It waits for the hand being played to end. The
+// players who fail to be seated keep their place in the waitlists.
+func (l *Lobby) fill(ctx context.Context, id string) error {
+	t := l.tables[id]
+	stakes := stakesOf(t)
+	failed := make(map[string]struct{})
+	for t.OpenSeats() > 0 {
+		waiter, ok := l.next(id, failed)
+		if !ok {
+			return nil
+		}
+
+		object := EventObject{ID: waiter.ID, Table: id, Stakes: stakes}
+		if _, err := t.Join(ctx, waiter.Name, waiter.ID, waiter.Chips); err != nil {
+			failed[waiter.ID] = struct{}{}
+			if err := l.broadcast(EventSeatFailed, object); err != nil {
+				return err
+			}
+			continue
+		}
+		// the player is seated at most once
+		if err := l.dequeue(waiter.ID, ""); err != nil {
+			return err
+		}
+		if err := l.broadcast(EventSeat, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// next returns the first player waiting for the table, then for its stakes, but the
+// players who have failed to be seated.
+func (l *Lobby) next(id string, failed map[string]struct{}) (Waiter, bool) {
+	lists := []*waitlist{l.waitlists[id]}
+	if list, exists := l.stakesWaitlists[stakesOf(l.tables[id])]; exists {
+		lists = append(lists, list)
+	}
+	for _, list := range lists {
+		for _, waiter := range *list {
+			if _, ok := failed[waiter.ID]; !ok {
+				return waiter, true
+			}
+		}
+	}
+	return Waiter{}, false
+}
+
+// dequeue removes the player from every waitlist, and notifies the players behind
+// that they have moved up. If action is not empty, it is broadcast for every waitlist
+// the player leaves.
+func (l *Lobby) dequeue(id string, action EventAction) error {
+	for tid, list := range l.waitlists {
+		object := EventObject{ID: id, Table: tid, Stakes: stakesOf(l.tables[tid])}
+		if err := l.removeWaiter(list, object, action); err != nil {
+			return err
+		}
+	}
+	for stakes, list := range l.stakesWaitlists {
+		if err := l.removeWaiter(list, EventObject{ID: id, Stakes: stakes}, action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Lobby) removeWaiter(list *waitlist, object EventObject, action EventAction) error {
+	if list.position(object.ID) == 0 {
+		return nil
+	}
+	moved := list.remove(object.ID)
+	if action != "" {
+		if err := l.broadcast(action, object); err != nil {
+			return err
+		}
+	}
+	for _, waiter := range moved {
+		object.ID, object.Position = waiter.ID, list.position(waiter.ID)
+		if err := l.broadcast(EventWaitlistMove, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Lobby) broadcast(action EventAction, object EventObject) error {
+	if err := l.broadcaster.Action(NewEvent(action, object)); err != nil {
+		return fmt.Errorf("broadcast lobby event, err: %w", err)
+	}
+	return nil
+}
+
+func stakesOf(t *table.Table) Stakes {
+	small, big := t.Blinds()
+	return Stakes{SmallBlind: small, BigBlind: big}
+}
@@ -0,0 +1,143 @@
+package lobby
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/yshngg/holdem/pkg/table"
+	"github.com/yshngg/holdem/pkg/watch"
+)
+
+// watchEvents returns the events of the lobby, read as they are broadcast.
+func watchEvents(t *testing.T, l *Lobby) <-chan watch.Event {
+	t.Helper()
+	w, err := l.Watch()
+	if err != nil {
+		t.Fatalf("watch lobby, err: %v", err)
+	}
+	t.Cleanup(w.Stop)
+	events := make(chan watch.Event, defaultQueueLength)
+	go func() {
+		for event := range w.Watch() {
+			events <- event
+		}
+	}()
+	return events
+}
+
+// await waits for the event of the action about the player.
+func await(t *testing.T, events <-chan watch.Event, action EventAction, id string) {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Action() == string(action) && event.Related().(EventObject).ID == id {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("no %s event for %s", action, id)
+		}
+	}
+}
+
+// seated returns the IDs of the players seated at the table, by seats.
+func seated(tb *table.Table) []string {
+	ids := make([]string, 0)
+	for _, p := range tb.Players() {
+		ids = append(ids, p.ID())
+	}
+	return ids
+}
+
+func TestFill(t *testing.T) {
+	l := New()
+	events := watchEvents(t, l)
+	stakes := Stakes{SmallBlind: 1, BigBlind: 2}
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := l.JoinStakesWaitlist(t.Context(), stakes, Waiter{ID: id, Name: id, Chips: 100}); err != nil {
+			t.Fatalf("join stakes waitlist, err: %v", err)
+		}
+	}
+
+	// the table is filled in the order the players have joined the waitlist
+	tb := table.New(table.WithCapacity(2), table.WithBlinds(1, 2))
+	if err := l.Add(t.Context(), "t", tb); err != nil {
+		t.Fatalf("add table, err: %v", err)
+	}
+	await(t, events, EventSeat, "a")
+	await(t, events, EventSeat, "b")
+	if got, want := seated(tb), []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("seated: %v, want: %v", got, want)
+	}
+	if position, err := l.JoinStakesWaitlist(t.Context(), stakes, Waiter{ID: "c", Name: "c", Chips: 100}); err != nil || position != 1 {
+		t.Errorf("position of c: %d, err: %v, want first in the waitlist", position, err)
+	}
+}
+
+func TestLeave(t *testing.T) {
+	l := New()
+	events := watchEvents(t, l)
+	tb := table.New(table.WithCapacity(2))
+	if err := l.Add(t.Context(), "t", tb); err != nil {
+		t.Fatalf("add table, err: %v", err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := l.JoinWaitlist(t.Context(), "t", Waiter{ID: id, Name: id, Chips: 100}); err != nil {
+			t.Fatalf("join waitlist, err: %v", err)
+		}
+	}
+
+	// no hand is played, c takes the seat at once
+	if err := l.Leave(t.Context(), "t", "a"); err != nil {
+		t.Fatalf("leave, err: %v", err)
+	}
+	await(t, events, EventSeat, "c")
+	if got, want := seated(tb), []string{"c", "b"}; !slices.Equal(got, want) {
+		t.Errorf("seated: %v, want: %v", got, want)
+	}
+
+	// a player leaving the table on its own, d waits for the seat to open once the
+	// hand is over
+	if _, err := l.JoinWaitlist(t.Context(), "t", Waiter{ID: "d", Name: "d", Chips: 100}); err != nil {
+		t.Fatalf("join waitlist, err: %v", err)
+	}
+	if err := tb.Leave(t.Context(), "b"); err != nil {
+		t.Fatalf("leave table, err: %v", err)
+	}
+	var notEnoughPlayers table.ErrNotEnoughPlayers
+	if err := tb.PlayHand(t.Context()); !errors.As(err, &notEnoughPlayers) {
+		t.Fatalf("play hand, err: %v, want: %T", err, notEnoughPlayers)
+	}
+	await(t, events, EventSeat, "d")
+	if got, want := seated(tb), []string{"c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("seated: %v, want: %v", got, want)
+	}
+}
+
+func TestSeatFailed(t *testing.T) {
+	l := New()
+	events := watchEvents(t, l)
+	tb := table.New(table.WithCapacity(2))
+	if err := l.Add(t.Context(), "t", tb); err != nil {
+		t.Fatalf("add table, err: %v", err)
+	}
+
+	// too few chips to buy in, the player keeps the place
+	if _, err := l.JoinWaitlist(t.Context(), "t", Waiter{ID: "short", Name: "short", Chips: 1}); err != nil {
+		t.Fatalf("join waitlist, err: %v", err)
+	}
+	await(t, events, EventSeatFailed, "short")
+	if _, err := l.JoinWaitlist(t.Context(), "t", Waiter{ID: "a", Name: "a", Chips: 100}); err != nil {
+		t.Fatalf("join waitlist, err: %v", err)
+	}
+	await(t, events, EventSeat, "a")
+	if got, want := seated(tb), []string{"a"}; !slices.Equal(got, want) {
+		t.Errorf("seated: %v, want: %v", got, want)
+	}
+	if position, err := l.JoinWaitlist(t.Context(), "t", Waiter{ID: "short", Name: "short", Chips: 1}); position != 1 {
+		t.Errorf("position of short: %d, err: %v, want first in the waitlist", position, err)
+	}
+}
@@ -0,0 +1,43 @@
+package lobby
+
+import "slices"
+
+// Waiter is a player waiting for a seat, with the chips to buy in with.
+type Waiter struct {
+	ID    string
+	Name  string
+	Chips int
+}
+
+// waitlist is a FIFO queue of the players waiting for a seat.
+type waitlist []Waiter
+
+// add appends the player to the queue, it returns the position of the player
+// starting from 1, and whether the player has been added.
+func (w *waitlist) add(waiter Waiter) (int, bool) {
+	if position := w.position(waiter.ID); position > 0 {
+		return position, false
+	}
+	*w = append(*w, waiter)
+	return len(*w), true
+}
+
+// remove removes the player from the queue, it returns the players behind, who
+// have moved up.
+func (w *waitlist) remove(id string) []Waiter {
+	index := slices.IndexFunc(*w, func(waiter Waiter) bool {
+		return waiter.ID == id
+	})
+	if index < 0 {
+		return nil
+	}
+	*w = slices.Delete(*w, index, index+1)
+	return (*w)[index:]
+}
+
+// position returns the position of the player starting from 1, zero if not queued.
+func (w waitlist) position(id string) int {
+	return slices.IndexFunc(w, func(waiter Waiter) bool {
+		return waiter.ID == id
+	}) + 1
+}
@@ -0,0 +1,36 @@
+package lobby
+
+import "testing"
+
+func TestWaitlist(t *testing.T) {
+	list := &waitlist{}
+	for _, id := range []string{"a", "b", "c"} {
+		if _, added := list.add(Waiter{ID: id}); !added {
+			t.Errorf("add %s: not added", id)
+		}
+	}
+	if position, added := list.add(Waiter{ID: "b"}); added || position != 2 {
+		t.Errorf("add b again: (%d, %v), want: (%d, %v)", position, added, 2, false)
+	}
+
+	moved := list.remove("a")
+	if len(moved) != 2 || moved[0].ID != "b" || moved[1].ID != "c" {
+		t.Errorf("moved: %v, want: [b c]", moved)
+	}
+	testCases := []struct {
+		id   string
+		want int
+	}{
+		{"a", 0},
+		{"b", 1},
+		{"c", 2},
+	}
+	for _, tc := range testCases {
+		if got := list.position(tc.id); got != tc.want {
+			t.Errorf("position of %s: %d, want: %d", tc.id, got, tc.want)
+		}
+	}
+	if moved := list.remove("a"); moved != nil {
+		t.Errorf("moved: %v, want: nil", moved)
+	}
+}
@@ -0,0 +1,133 @@
+package ofc
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/hand"
+)
+
+// Row is one of the three hands set on a board, from the top.
+type Row int
+
+const (
+	Top    Row = iota // three cards, the weakest hand
+	Middle            // five cards
+	Bottom            // five cards, the strongest hand
+)
+
+func (r Row) String() string {
+	switch r {
+	case Top:
+		return "Top"
+	case Middle:
+		return "Middle"
+	case Bottom:
+		return "Bottom"
+	default:
+		return "Invalid"
+	}
+}
+
+// Size returns the number of cards the row holds.
+func (r Row) Size() int {
+	if r == Top {
+		return 3
+	}
+	return 5
+}
+
+var rows = []Row{Top, Middle, Bottom}
+
+type ErrInvalidRow struct {
+	row Row
+}
+
+func (e ErrInvalidRow) Error() string {
+	return fmt.Sprintf("invalid row: %d", e.row)
+}
+
+type ErrRowFull struct {
+	row Row
+}
+
+func (e ErrRowFull) Error() string {
+	return fmt.Sprintf("row full: %s", e.row)
+}
+
+type ErrBoardNotFull struct{}
+
+func (e ErrBoardNotFull) Error() string {
+	return "board not full"
+}
+
+// Board holds the cards a player has set face up in the three rows. Cards once set
+// are never moved.
+type Board struct {
+	rows [3][]card.Card
+}
+
+// NewBoard returns an empty board.
+func NewBoard() *Board {
+	return &Board{}
+}
+
+// Place sets the card in the row.
+func (b *Board) Place(row Row, c card.Card) error {
+	if row < Top || row > Bottom {
+		return ErrInvalidRow{row: row}
+	}
+	if len(b.rows[row]) >= row.Size() {
+		return ErrRowFull{row: row}
+	}
+	b.rows[row] = append(b.rows[row], c)
+	return nil
+}
+
+// Row returns the cards set in the row.
+func (b *Board) Row(row Row) []card.Card {
+	return slices.Clone(b.rows[row])
+}
+
+// Left returns the number of cards left to set.
+func (b *Board) Left() int {
+	left := 0
+	for _, row := range rows {
+		left += row.Size() - len(b.rows[row])
+	}
+	return left
+}
+
+// Full reports whether all 13 cards are set.
+func (b *Board) Full() bool {
+	return b.Left() == 0
+}
+
+// Scores returns the score of every row, from the top.
+func (b *Board) Scores() ([3]hand.Score, error) {
+	var scores [3]hand.Score
+	if !b.Full() {
+		return scores, ErrBoardNotFull{}
+	}
+	var err error
+	if scores[Top], err = hand.EvaluateThree(b.rows[Top]); err != nil {
+		return scores, fmt.Errorf("evaluate %s, err: %w", Top, err)
+	}
+	for _, row := range []Row{Middle, Bottom} {
+		if scores[row], err = hand.Evaluate(b.rows[row]); err != nil {
+			return scores, fmt.Errorf("evaluate %s, err: %w", row, err)
+		}
+	}
+	return scores, nil
+}
+
+// Foul reports whether the rows are not set from the weakest at the top to the
+// strongest at the bottom. A fouled board loses every row and earns no royalties.
+func (b *Board) Foul() (bool, error) {
+	scores, err := b.Scores()
+	if err != nil {
+		return false, err
+	}
+	return scores[Top] > scores[Middle] || scores[Middle] > scores[Bottom], nil
+}
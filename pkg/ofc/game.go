@@ -0,0 +1,255 @@
+package ofc
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/deck"
+)
+
+const (
+	MinPlayerCount = 2
+	MaxPlayerCount = 3
+
+	// initialCards are dealt to every player first, then one card at a time.
+	initialCards = 5
+
+	// fantasylandCards are dealt at once to a player in Fantasyland, who sets 13 of
+	// them and discards the last one.
+	fantasylandCards = 14
+)
+
+type ErrInvalidPlayerCount struct {
+	count int
+}
+
+func (e ErrInvalidPlayerCount) Error() string {
+	return fmt.Sprintf("invalid player count: %d", e.count)
+}
+
+type ErrPlacementPending struct {
+	seat int
+}
+
+func (e ErrPlacementPending) Error() string {
+	return fmt.Sprintf("player at seat %d has not set the cards dealt", e.seat)
+}
+
+type ErrCardNotDealt struct {
+	card card.Card
+}
+
+func (e ErrCardNotDealt) Error() string {
+	return fmt.Sprintf("card not dealt: %s", e.card)
+}
+
+type ErrInvalidPlacementCount struct {
+	count, want int
+}
+
+func (e ErrInvalidPlacementCount) Error() string {
+	return fmt.Sprintf("invalid placement count: %d, want %d", e.count, e.want)
+}
+
+type ErrGameOver struct{}
+
+func (e ErrGameOver) Error() string {
+	return "game over"
+}
+
+type ErrGameNotOver struct{}
+
+func (e ErrGameNotOver) Error() string {
+	return "game not over"
+}
+
+// Placement sets a dealt card in a row.
+type Placement struct {
+	Card card.Card
+	Row  Row
+}
+
+// Game is a hand of open-face Chinese poker: the players set the cards dealt to
+// them, five first and then one at a time, in three rows of their board until all
+// 13 cards are set. The boards are then compared in pairs.
+type Game struct {
+	deck   *deck.Deck
+	boards []*Board
+
+	// fantasyland marks the players dealt all their cards at once.
+	fantasyland []bool
+
+	// dealt holds the cards dealt to every player and not set yet.
+	dealt [][]card.Card
+}
+
+type Option func(*Game)
+
+// WithDeck deals from the given deck, in order, instead of a shuffled one.
+func WithDeck(_deck *deck.Deck) Option {
+	return func(g *Game) {
+		g.deck = _deck
+	}
+}
+
+// WithFantasyland deals the players at the given seats all their cards at once.
+func WithFantasyland(seats ...int) Option {
+	return func(g *Game) {
+		for _, seat := range seats {
+			if seat >= 0 && seat < len(g.fantasyland) {
+				g.fantasyland[seat] = true
+			}
+		}
+	}
+}
+
+// New returns a game for the given number of players.
+func New(playerCount int, opts ...Option) (*Game, error) {
+	if playerCount < MinPlayerCount || playerCount > MaxPlayerCount {
+		return nil, ErrInvalidPlayerCount{count: playerCount}
+	}
+	g := &Game{
+		boards:      make([]*Board, playerCount),
+		fantasyland: make([]bool, playerCount),
+		dealt:       make([][]card.Card, playerCount),
+	}
+	for i := range g.boards {
+		g.boards[i] = NewBoard()
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.deck == nil {
+		g.deck = deck.New()
+		rand.Shuffle(g.deck.Len(), g.deck.Swap)
+	}
+	return g, nil
+}
+
+// Deal deals the next cards to every player who has cards left to set: five to
+// start, then one, and all of them at once in Fantasyland. Every player must have
+// set the cards dealt before.
+func (g *Game) Deal() ([][]card.Card, error) {
+	if g.Over() {
+		return nil, ErrGameOver{}
+	}
+	for seat, dealt := range g.dealt {
+		if len(dealt) > 0 {
+			return nil, ErrPlacementPending{seat: seat}
+		}
+	}
+	for seat, board := range g.boards {
+		count := 1
+		switch {
+		case board.Full():
+			count = 0
+		case g.fantasyland[seat]:
+			count = fantasylandCards
+		case board.Left() == 13:
+			count = initialCards
+		}
+		for range count {
+			c := g.deck.Pop()
+			if c == nil {
+				return nil, fmt.Errorf("deal to seat %d, err: %w", seat, ErrGameOver{})
+			}
+			g.dealt[seat] = append(g.dealt[seat], *c)
+		}
+	}
+	dealt := make([][]card.Card, len(g.dealt))
+	for seat := range g.dealt {
+		dealt[seat] = slices.Clone(g.dealt[seat])
+	}
+	return dealt, nil
+}
+
+// Place sets the cards dealt to the player at the seat, all of them, or 13 in
+// Fantasyland where the last card is discarded. Either all the placements are set
+// or none.
+func (g *Game) Place(seat int, placements []Placement) error {
+	if seat < 0 || seat >= len(g.boards) {
+		return fmt.Errorf("invalid seat: %d", seat)
+	}
+	board, dealt := g.boards[seat], g.dealt[seat]
+	if want := min(len(dealt), board.Left()); len(placements) != want {
+		return ErrInvalidPlacementCount{count: len(placements), want: want}
+	}
+
+	placed := *board
+	for _, row := range rows {
+		placed.rows[row] = slices.Clone(board.rows[row])
+	}
+	left := slices.Clone(dealt)
+	for _, placement := range placements {
+		i := slices.Index(left, placement.Card)
+		if i < 0 {
+			return ErrCardNotDealt{card: placement.Card}
+		}
+		left = slices.Delete(left, i, i+1)
+		if err := placed.Place(placement.Row, placement.Card); err != nil {
+			return err
+		}
+	}
+	*board = placed
+	g.dealt[seat] = nil
+	return nil
+}
+
+// Board returns the board of the player at the seat.
+func (g *Game) Board(seat int) *Board {
+	return g.boards[seat]
+}
+
+// Over reports whether every board is full.
+func (g *Game) Over() bool {
+	for _, board := range g.boards {
+		if !board.Full() {
+			return false
+		}
+	}
+	return true
+}
+
+// Scores returns the points every player wins against all the others, zero-sum.
+func (g *Game) Scores() ([]int, error) {
+	if !g.Over() {
+		return nil, ErrGameNotOver{}
+	}
+	scores := make([]int, len(g.boards))
+	for i := range g.boards {
+		for j := i + 1; j < len(g.boards); j++ {
+			points, err := Compare(g.boards[i], g.boards[j])
+			if err != nil {
+				return nil, fmt.Errorf("compare seats %d and %d, err: %w", i, j, err)
+			}
+			scores[i] += points
+			scores[j] -= points
+		}
+	}
+	return scores, nil
+}
+
+// Fantasyland returns the seats of the players dealt into Fantasyland in the next
+// game: those who qualify, and those in Fantasyland who stay.
+func (g *Game) Fantasyland() ([]int, error) {
+	if !g.Over() {
+		return nil, ErrGameNotOver{}
+	}
+	seats := make([]int, 0)
+	for seat, board := range g.boards {
+		enters := Qualifies
+		if g.fantasyland[seat] {
+			enters = Stays
+		}
+		ok, err := enters(board)
+		if err != nil {
+			return nil, fmt.Errorf("fantasyland of seat %d, err: %w", seat, err)
+		}
+		if ok {
+			seats = append(seats, seat)
+		}
+	}
+	return seats, nil
+}
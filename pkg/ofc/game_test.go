@@ -0,0 +1,108 @@
+package ofc
+
+import (
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+)
+
+// place sets the cards in the first rows with room left.
+func place(t *testing.T, g *Game, seat int, cards []card.Card) {
+	t.Helper()
+	board := g.Board(seat)
+	placements := make([]Placement, 0, len(cards))
+	counts := [3]int{len(board.Row(Top)), len(board.Row(Middle)), len(board.Row(Bottom))}
+	for _, c := range cards[:min(len(cards), board.Left())] {
+		for _, row := range []Row{Bottom, Middle, Top} {
+			if counts[row] < row.Size() {
+				counts[row]++
+				placements = append(placements, Placement{Card: c, Row: row})
+				break
+			}
+		}
+	}
+	if err := g.Place(seat, placements); err != nil {
+		t.Fatalf("Place(%d, %v).err = %v", seat, placements, err)
+	}
+}
+
+func TestGame(t *testing.T) {
+	g, err := New(3, WithFantasyland(2))
+	if err != nil {
+		t.Fatalf("New().err = %v", err)
+	}
+	deals := 0
+	for !g.Over() {
+		dealt, err := g.Deal()
+		if err != nil {
+			t.Fatalf("Deal().err = %v", err)
+		}
+		if deals == 0 {
+			for seat, want := range []int{initialCards, initialCards, fantasylandCards} {
+				if len(dealt[seat]) != want {
+					t.Errorf("Deal() to seat %d = %d cards, want %d", seat, len(dealt[seat]), want)
+				}
+			}
+			if _, err := g.Deal(); err != (ErrPlacementPending{seat: 0}) {
+				t.Errorf("Deal().err = %v, want %v", err, ErrPlacementPending{seat: 0})
+			}
+		}
+		for seat, cards := range dealt {
+			if len(cards) > 0 {
+				place(t, g, seat, cards)
+			}
+		}
+		deals++
+	}
+	if deals != 9 {
+		t.Errorf("deals = %d, want 9", deals)
+	}
+
+	scores, err := g.Scores()
+	if err != nil {
+		t.Fatalf("Scores().err = %v", err)
+	}
+	sum := 0
+	for _, score := range scores {
+		sum += score
+	}
+	if sum != 0 {
+		t.Errorf("Scores() = %v, want zero-sum", scores)
+	}
+	if _, err := g.Deal(); err != (ErrGameOver{}) {
+		t.Errorf("Deal().err = %v, want %v", err, ErrGameOver{})
+	}
+}
+
+func TestPlace(t *testing.T) {
+	g, err := New(2)
+	if err != nil {
+		t.Fatalf("New().err = %v", err)
+	}
+	dealt, err := g.Deal()
+	if err != nil {
+		t.Fatalf("Deal().err = %v", err)
+	}
+
+	tooFew := []Placement{{Card: dealt[0][0], Row: Bottom}}
+	if err := g.Place(0, tooFew); err != (ErrInvalidPlacementCount{count: 1, want: 5}) {
+		t.Errorf("Place().err = %v, want %v", err, ErrInvalidPlacementCount{count: 1, want: 5})
+	}
+	overflow := make([]Placement, 0, len(dealt[0]))
+	for _, c := range dealt[0] {
+		overflow = append(overflow, Placement{Card: c, Row: Top})
+	}
+	if err := g.Place(0, overflow); err != (ErrRowFull{row: Top}) {
+		t.Errorf("Place().err = %v, want %v", err, ErrRowFull{row: Top})
+	}
+	if left := g.Board(0).Left(); left != 13 {
+		t.Errorf("Left() = %d after a rejected placement, want 13", left)
+	}
+	notDealt := make([]Placement, 0, len(dealt[1]))
+	for _, c := range dealt[1] {
+		notDealt = append(notDealt, Placement{Card: c, Row: Bottom})
+	}
+	if err := g.Place(0, notDealt); err != (ErrCardNotDealt{card: dealt[1][0]}) {
+		t.Errorf("Place().err = %v, want %v", err, ErrCardNotDealt{card: dealt[1][0]})
+	}
+}
@@ -0,0 +1,150 @@
+package ofc
+
+import (
+	"cmp"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/hand"
+	"github.com/yshngg/holdem/pkg/rank"
+)
+
+// scoopBonus is the extra points for winning all three rows.
+const scoopBonus = 3
+
+var (
+	bottomRoyalties = map[hand.Hand]int{
+		hand.Straight:      2,
+		hand.Flush:         4,
+		hand.FullHouse:     6,
+		hand.FourOfAKind:   10,
+		hand.StraightFlush: 15,
+		hand.RoyalFlush:    25,
+	}
+	middleRoyalties = map[hand.Hand]int{
+		hand.ThreeOfAKind:  2,
+		hand.Straight:      4,
+		hand.Flush:         8,
+		hand.FullHouse:     12,
+		hand.FourOfAKind:   20,
+		hand.StraightFlush: 30,
+		hand.RoyalFlush:    50,
+	}
+)
+
+// Royalties returns the bonus points the rows of the board earn, none if fouled.
+// The top earns from a pair of sixes (1) to three aces (22).
+func Royalties(b *Board) (int, error) {
+	foul, err := b.Foul()
+	if err != nil || foul {
+		return 0, err
+	}
+	scores, err := b.Scores()
+	if err != nil {
+		return 0, err
+	}
+	royalties := middleRoyalties[scores[Middle].Hand()] + bottomRoyalties[scores[Bottom].Hand()]
+	switch value, r := top(b.rows[Top]); value {
+	case hand.Pair:
+		royalties += max(int(r-rank.Five), 0)
+	case hand.ThreeOfAKind:
+		royalties += int(r-rank.Two) + 10
+	}
+	return royalties, nil
+}
+
+// Compare returns the points the first board wins from the second one, negative if
+// it loses: a point a row, the scoop bonus for all three, and the difference in
+// royalties. A fouled board loses every row to a board which is not.
+func Compare(a, b *Board) (int, error) {
+	foulA, err := a.Foul()
+	if err != nil {
+		return 0, err
+	}
+	foulB, err := b.Foul()
+	if err != nil {
+		return 0, err
+	}
+	royaltiesA, err := Royalties(a)
+	if err != nil {
+		return 0, err
+	}
+	royaltiesB, err := Royalties(b)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case foulA && foulB:
+		return 0, nil
+	case foulA:
+		return -len(rows) - scoopBonus - royaltiesB, nil
+	case foulB:
+		return len(rows) + scoopBonus + royaltiesA, nil
+	}
+
+	scoresA, err := a.Scores()
+	if err != nil {
+		return 0, err
+	}
+	scoresB, err := b.Scores()
+	if err != nil {
+		return 0, err
+	}
+	points := 0
+	for _, row := range rows {
+		points += cmp.Compare(scoresA[row], scoresB[row])
+	}
+	if points == len(rows) {
+		points += scoopBonus
+	} else if points == -len(rows) {
+		points -= scoopBonus
+	}
+	return points + royaltiesA - royaltiesB, nil
+}
+
+// Qualifies reports whether the board enters Fantasyland, queens or better at the
+// top without fouling.
+func Qualifies(b *Board) (bool, error) {
+	foul, err := b.Foul()
+	if err != nil || foul {
+		return false, err
+	}
+	value, r := top(b.rows[Top])
+	return value == hand.ThreeOfAKind || value == hand.Pair && r >= rank.Queen, nil
+}
+
+// Stays reports whether the board of a player in Fantasyland stays for another
+// hand: three of a kind at the top, a full house or better in the middle, or four
+// of a kind or better at the bottom, without fouling.
+func Stays(b *Board) (bool, error) {
+	foul, err := b.Foul()
+	if err != nil || foul {
+		return false, err
+	}
+	scores, err := b.Scores()
+	if err != nil {
+		return false, err
+	}
+	value, _ := top(b.rows[Top])
+	return value == hand.ThreeOfAKind || scores[Middle].Hand() >= hand.FullHouse || scores[Bottom].Hand() >= hand.FourOfAKind, nil
+}
+
+// top returns the value of the top row and the rank of its pair or three of a kind,
+// or of its highest card.
+func top(cards []card.Card) (hand.Hand, rank.Rank) {
+	counts := make(map[rank.Rank]int)
+	best := rank.Rank(0)
+	for _, c := range cards {
+		counts[c.Rank()]++
+		if counts[c.Rank()] > counts[best] || counts[c.Rank()] == counts[best] && c.Rank() > best {
+			best = c.Rank()
+		}
+	}
+	switch counts[best] {
+	case 3:
+		return hand.ThreeOfAKind, best
+	case 2:
+		return hand.Pair, best
+	default:
+		return hand.HighCard, best
+	}
+}
@@ -0,0 +1,146 @@
+package ofc
+
+import (
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+func newBoard(t *testing.T, top, middle, bottom []card.Card) *Board {
+	t.Helper()
+	b := NewBoard()
+	for row, cards := range [][]card.Card{top, middle, bottom} {
+		for _, c := range cards {
+			if err := b.Place(Row(row), c); err != nil {
+				t.Fatalf("Place(%s, %v).err = %v", Row(row), c, err)
+			}
+		}
+	}
+	return b
+}
+
+var (
+	// queens at the top, two pairs in the middle, a flush at the bottom
+	queens = []card.Card{
+		card.New(rank.Queen, suit.Clubs),
+		card.New(rank.Queen, suit.Hearts),
+		card.New(rank.Two, suit.Spades),
+	}
+	twoPairs = []card.Card{
+		card.New(rank.King, suit.Clubs),
+		card.New(rank.King, suit.Hearts),
+		card.New(rank.Three, suit.Spades),
+		card.New(rank.Three, suit.Diamonds),
+		card.New(rank.Four, suit.Clubs),
+	}
+	flush = []card.Card{
+		card.New(rank.Ace, suit.Hearts),
+		card.New(rank.Ten, suit.Hearts),
+		card.New(rank.Eight, suit.Hearts),
+		card.New(rank.Six, suit.Hearts),
+		card.New(rank.Five, suit.Hearts),
+	}
+
+	// high cards everywhere, set in order
+	lowTop = []card.Card{
+		card.New(rank.Nine, suit.Clubs),
+		card.New(rank.Seven, suit.Diamonds),
+		card.New(rank.Five, suit.Spades),
+	}
+	lowMiddle = []card.Card{
+		card.New(rank.Jack, suit.Clubs),
+		card.New(rank.Nine, suit.Diamonds),
+		card.New(rank.Seven, suit.Clubs),
+		card.New(rank.Six, suit.Diamonds),
+		card.New(rank.Four, suit.Diamonds),
+	}
+	lowBottom = []card.Card{
+		card.New(rank.Jack, suit.Spades),
+		card.New(rank.Ten, suit.Diamonds),
+		card.New(rank.Eight, suit.Clubs),
+		card.New(rank.Six, suit.Clubs),
+		card.New(rank.Two, suit.Clubs),
+	}
+)
+
+func TestFoul(t *testing.T) {
+	if foul, err := newBoard(t, queens, twoPairs, flush).Foul(); err != nil || foul {
+		t.Errorf("Foul() = %v, %v, want false", foul, err)
+	}
+	// the pair of queens at the top beats the high card in the middle
+	if foul, err := newBoard(t, queens, lowMiddle, flush).Foul(); err != nil || !foul {
+		t.Errorf("Foul() = %v, %v, want true", foul, err)
+	}
+	if _, err := NewBoard().Foul(); err != (ErrBoardNotFull{}) {
+		t.Errorf("Foul().err = %v, want %v", err, ErrBoardNotFull{})
+	}
+}
+
+func TestRoyalties(t *testing.T) {
+	testCases := []struct {
+		name                string
+		top, middle, bottom []card.Card
+		want                int
+	}{
+		// queens 7, flush at the bottom 4
+		{name: "QueensFlush", top: queens, middle: twoPairs, bottom: flush, want: 11},
+		{name: "None", top: lowTop, middle: lowMiddle, bottom: lowBottom, want: 0},
+		{name: "Foul", top: queens, middle: lowMiddle, bottom: flush, want: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Royalties(newBoard(t, tc.top, tc.middle, tc.bottom))
+			if err != nil {
+				t.Fatalf("Royalties().err = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Royalties() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		name string
+		a, b *Board
+		want int
+	}{
+		// three rows, the scoop and 11 in royalties
+		{name: "Scoop", a: newBoard(t, queens, twoPairs, flush), b: newBoard(t, lowTop, lowMiddle, lowBottom), want: 17},
+		{name: "Fouled", a: newBoard(t, queens, lowMiddle, flush), b: newBoard(t, lowTop, lowMiddle, lowBottom), want: -6},
+		{name: "BothFouled", a: newBoard(t, queens, lowMiddle, flush), b: newBoard(t, queens, lowMiddle, flush), want: 0},
+		{name: "Tie", a: newBoard(t, lowTop, lowMiddle, lowBottom), b: newBoard(t, lowTop, lowMiddle, lowBottom), want: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Compare(tc.a, tc.b)
+			if err != nil {
+				t.Fatalf("Compare().err = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Compare() = %d, want %d", got, tc.want)
+			}
+			if reverse, _ := Compare(tc.b, tc.a); reverse != -got {
+				t.Errorf("Compare() reversed = %d, want %d", reverse, -got)
+			}
+		})
+	}
+}
+
+func TestFantasyland(t *testing.T) {
+	if ok, err := Qualifies(newBoard(t, queens, twoPairs, flush)); err != nil || !ok {
+		t.Errorf("Qualifies() = %v, %v, want true", ok, err)
+	}
+	if ok, err := Qualifies(newBoard(t, lowTop, lowMiddle, lowBottom)); err != nil || ok {
+		t.Errorf("Qualifies() = %v, %v, want false", ok, err)
+	}
+	// queens do not keep the player in Fantasyland
+	if ok, err := Stays(newBoard(t, queens, twoPairs, flush)); err != nil || ok {
+		t.Errorf("Stays() = %v, %v, want false", ok, err)
+	}
+}
@@ -0,0 +1,108 @@
+package payout
+
+import (
+	"math/bits"
+	"math/rand"
+)
+
+const (
+	// maxExactPlayers is the largest field the exact ICM is computed for,
+	// its cost doubles with every player.
+	maxExactPlayers = 16
+
+	defaultTrials = 100000
+)
+
+// ICM converts the stacks into the expected prizes with the Independent Chip Model,
+// where the chance of a player finishing in the next best place is proportional to
+// the stack among the players left. It is computed exactly for small fields and
+// approximated by Monte Carlo simulation for large ones.
+// prizes are the amounts paid, from the first place.
+func ICM(stacks []int, prizes []int) []float64 {
+	if len(stacks) <= maxExactPlayers {
+		return ExactICM(stacks, prizes)
+	}
+	return MonteCarloICM(stacks, prizes, defaultTrials)
+}
+
+// ExactICM computes the ICM over all the finishing orders of the paid places.
+func ExactICM(stacks []int, prizes []int) []float64 {
+	n := len(stacks)
+	equities := make([]float64, n)
+	if n == 0 {
+		return equities
+	}
+	total := 0
+	for _, stack := range stacks {
+		total += stack
+	}
+	paid := min(len(prizes), n)
+
+	// probabilities[mask] is the probability the players in mask take the best places
+	probabilities := make([]float64, 1<<n)
+	probabilities[0] = 1
+	chips := make([]int, 1<<n)
+	for mask := range len(probabilities) {
+		if mask > 0 {
+			low := bits.TrailingZeros(uint(mask))
+			chips[mask] = chips[mask&(mask-1)] + stacks[low]
+		}
+		place := bits.OnesCount(uint(mask))
+		if probabilities[mask] == 0 || place >= paid {
+			continue
+		}
+		left := total - chips[mask]
+		if left <= 0 {
+			continue
+		}
+		for i, stack := range stacks {
+			if mask&(1<<i) != 0 || stack <= 0 {
+				continue
+			}
+			p := probabilities[mask] * float64(stack) / float64(left)
+			equities[i] += p * float64(prizes[place])
+			probabilities[mask|1<<i] += p
+		}
+	}
+	return equities
+}
+
+// MonteCarloICM approximates the ICM by sampling finishing orders.
+func MonteCarloICM(stacks []int, prizes []int, trials int) []float64 {
+	n := len(stacks)
+	equities := make([]float64, n)
+	if n == 0 || trials <= 0 {
+		return equities
+	}
+	paid := min(len(prizes), n)
+
+	// the players racing for the next best place with exponential times of rates
+	// proportional to their stacks finish in the order of the model
+	times := make([]float64, n)
+	order := make([]int, 0, n)
+	for range trials {
+		order = order[:0]
+		for i, stack := range stacks {
+			if stack <= 0 {
+				continue
+			}
+			times[i] = rand.ExpFloat64() / float64(stack)
+			order = append(order, i)
+		}
+		for place := range min(paid, len(order)) {
+			// select the player finishing in the place
+			best := place
+			for j := place + 1; j < len(order); j++ {
+				if times[order[j]] < times[order[best]] {
+					best = j
+				}
+			}
+			order[place], order[best] = order[best], order[place]
+			equities[order[place]] += float64(prizes[place])
+		}
+	}
+	for i := range equities {
+		equities[i] /= float64(trials)
+	}
+	return equities
+}
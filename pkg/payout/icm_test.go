@@ -0,0 +1,65 @@
+package payout
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExactICM(t *testing.T) {
+	testCases := []struct {
+		name   string
+		stacks []int
+		prizes []int
+		want   []float64
+	}{
+		{
+			name:   "EqualStacks",
+			stacks: []int{100, 100, 100},
+			prizes: []int{50, 30, 20},
+			want:   []float64{100.0 / 3, 100.0 / 3, 100.0 / 3},
+		},
+		{
+			name:   "WinnerTakesAll",
+			stacks: []int{1000, 3000},
+			prizes: []int{100},
+			want:   []float64{25, 75},
+		},
+		{
+			name:   "TwoPaid",
+			stacks: []int{50, 30, 20},
+			prizes: []int{70, 30},
+			want:   []float64{45.178571, 32.25, 22.571429},
+		},
+		{
+			name:   "Busted",
+			stacks: []int{0, 500, 500},
+			prizes: []int{60, 40},
+			want:   []float64{0, 50, 50},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ExactICM(tc.stacks, tc.prizes)
+			for i := range tc.want {
+				if math.Abs(got[i]-tc.want[i]) > 1e-5 {
+					t.Errorf("equities: %v, want: %v", got, tc.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestMonteCarloICM(t *testing.T) {
+	stacks := []int{5000, 3000, 2500, 1500, 1000, 500}
+	prizes := []int{500, 300, 200}
+	exact := ExactICM(stacks, prizes)
+	got := MonteCarloICM(stacks, prizes, 200000)
+	for i := range exact {
+		if math.Abs(got[i]-exact[i]) > 5 {
+			t.Errorf("equities: %v, want about: %v", got, exact)
+			break
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package payout
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Structure is the percentages of the prize pool paid by place, from the first place.
+type Structure []float64
+
+// WinnerTakesAll pays the whole prize pool to the first place.
+var WinnerTakesAll = Structure{100}
+
+type ErrInvalidStructure struct {
+	reason string
+}
+
+func (e ErrInvalidStructure) Error() string {
+	return fmt.Sprintf("invalid payout structure: %s", e.reason)
+}
+
+// Validate checks the percentages are not negative, do not go up with the places,
+// and do not pay more than the prize pool.
+func (s Structure) Validate() error {
+	total := 0.0
+	for i, percent := range s {
+		if percent < 0 {
+			return ErrInvalidStructure{reason: fmt.Sprintf("negative percentage of place %d", i+1)}
+		}
+		if i > 0 && percent > s[i-1] {
+			return ErrInvalidStructure{reason: fmt.Sprintf("place %d pays more than place %d", i+1, i)}
+		}
+		total += percent
+	}
+	if total > 100 {
+		return ErrInvalidStructure{reason: fmt.Sprintf("pays %.2f%% of the prize pool", total)}
+	}
+	return nil
+}
+
+// Paid returns the number of places paid.
+func (s Structure) Paid() int {
+	return len(s)
+}
+
+// Amounts returns the amount paid to every place, from the first place.
+// The leftover of rounding down goes to the first place.
+func (s Structure) Amounts(pool int) []int {
+	amounts := make([]int, len(s))
+	leftover := pool
+	for i, percent := range s {
+		amounts[i] = int(float64(pool) * percent / 100)
+		leftover -= amounts[i]
+	}
+	if len(amounts) > 0 && leftover > 0 {
+		amounts[0] += leftover
+	}
+	return amounts
+}
+
+// Prizes returns the prize of every given place. Players sharing a place split the
+// amounts of the places they cover, the odd chips go to the first of them.
+func (s Structure) Prizes(pool int, places []int) []int {
+	amounts := s.Amounts(pool)
+	amount := func(place int) int {
+		if place < 1 || place > len(amounts) {
+			return 0
+		}
+		return amounts[place-1]
+	}
+
+	count := make(map[int]int)
+	for _, place := range places {
+		count[place]++
+	}
+	share := make(map[int]int)
+	odd := make(map[int]int)
+	for place, n := range count {
+		total := 0
+		for i := range n {
+			total += amount(place + i)
+		}
+		share[place], odd[place] = total/n, total%n
+	}
+
+	prizes := make([]int, len(places))
+	for i, place := range places {
+		prizes[i] = share[place]
+		if odd[place] > 0 {
+			prizes[i]++
+			odd[place]--
+		}
+	}
+	return prizes
+}
+
+// Places returns the finishing places of the players busted in the same hand, out of
+// the players remaining before it. The bigger the stack starting the hand, the better
+// the place, players busted with the same stack share a place.
+func Places(busted []string, stacks map[string]int, remaining int) map[string]int {
+	sorted := slices.Clone(busted)
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		return stacks[b] - stacks[a]
+	})
+	places := make(map[string]int, len(sorted))
+	for i, id := range sorted {
+		place := remaining - len(sorted) + i + 1
+		if i > 0 && stacks[id] == stacks[sorted[i-1]] {
+			place = places[sorted[i-1]]
+		}
+		places[id] = place
+	}
+	return places
+}
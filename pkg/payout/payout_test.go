@@ -0,0 +1,134 @@
+package payout
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStructureValidate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		structure Structure
+		valid     bool
+	}{
+		{"WinnerTakesAll", WinnerTakesAll, true},
+		{"TopThree", Structure{50, 30, 20}, true},
+		{"Negative", Structure{110, -10}, false},
+		{"Ascending", Structure{30, 70}, false},
+		{"OverPaid", Structure{60, 50}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.structure.Validate()
+			if (err == nil) != tc.valid {
+				t.Errorf("err: %v, valid: %v", err, tc.valid)
+			}
+		})
+	}
+}
+
+func TestStructurePrizes(t *testing.T) {
+	testCases := []struct {
+		name      string
+		pool      int
+		structure Structure
+		places    []int
+		want      []int
+	}{
+		{
+			name:      "WinnerTakesAll",
+			pool:      100,
+			structure: WinnerTakesAll,
+			places:    []int{3, 2, 1},
+			want:      []int{0, 0, 100},
+		},
+		{
+			name:      "TopThree",
+			pool:      1000,
+			structure: Structure{50, 30, 20},
+			places:    []int{6, 5, 4, 3, 2, 1},
+			want:      []int{0, 0, 0, 200, 300, 500},
+		},
+		{
+			name:      "RoundingToWinner",
+			pool:      101,
+			structure: Structure{65, 35},
+			places:    []int{2, 1},
+			want:      []int{35, 66},
+		},
+		{
+			name:      "FractionalPercentages",
+			pool:      1000,
+			structure: Structure{47.5, 32.5, 20},
+			places:    []int{3, 2, 1},
+			want:      []int{200, 325, 475},
+		},
+		{
+			name:      "TieSplitsPlaces",
+			pool:      1000,
+			structure: Structure{50, 30, 20},
+			places:    []int{2, 2, 1},
+			want:      []int{250, 250, 500},
+		},
+		{
+			name:      "TieOddChip",
+			pool:      100,
+			structure: Structure{60, 25, 15},
+			places:    []int{4, 3, 3, 2, 1},
+			want:      []int{0, 8, 7, 25, 60},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.structure.Prizes(tc.pool, tc.places)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("prizes: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPlaces(t *testing.T) {
+	testCases := []struct {
+		name      string
+		busted    []string
+		stacks    map[string]int
+		remaining int
+		want      map[string]int
+	}{
+		{
+			name:      "One",
+			busted:    []string{"a"},
+			stacks:    map[string]int{"a": 100},
+			remaining: 10,
+			want:      map[string]int{"a": 10},
+		},
+		{
+			name:      "BiggerStackPlacesBetter",
+			busted:    []string{"a", "b"},
+			stacks:    map[string]int{"a": 100, "b": 300},
+			remaining: 10,
+			want:      map[string]int{"a": 10, "b": 9},
+		},
+		{
+			name:      "Tie",
+			busted:    []string{"a", "b", "c"},
+			stacks:    map[string]int{"a": 100, "b": 300, "c": 100},
+			remaining: 3,
+			want:      map[string]int{"a": 2, "b": 1, "c": 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Places(tc.busted, tc.stacks, tc.remaining)
+			for id, place := range tc.want {
+				if got[id] != place {
+					t.Errorf("place of %s: %d, want: %d", id, got[id], place)
+				}
+			}
+		})
+	}
+}
@@ -12,6 +12,23 @@ const (
 	ActionAllIn
 	ActionShowHoleCards
 	ActionHideHoleCards
+
+	// ActionAcceptRunouts and ActionDeclineRunouts answer the offer to run the rest
+	// of the board several times once all the players are all-in.
+	ActionAcceptRunouts
+	ActionDeclineRunouts
+
+	// ActionCashOut sells the equity of an all-in player for the chips offered before
+	// the runout, ActionDeclineCashOut keeps playing for the pot.
+	ActionCashOut
+	ActionDeclineCashOut
+
+	// ActionDraw discards hole cards for as many new ones in draw games, discarding
+	// none stands pat.
+	ActionDraw
+
+	// ActionDiscard discards hole cards without replacement, as in pineapple.
+	ActionDiscard
 )
 
 func (at ActionType) String() string {
@@ -32,6 +49,18 @@ func (at ActionType) String() string {
 		return "ShowHoleCards"
 	case ActionHideHoleCards:
 		return "HideHoleCards"
+	case ActionAcceptRunouts:
+		return "AcceptRunouts"
+	case ActionDeclineRunouts:
+		return "DeclineRunouts"
+	case ActionCashOut:
+		return "CashOut"
+	case ActionDeclineCashOut:
+		return "DeclineCashOut"
+	case ActionDraw:
+		return "Draw"
+	case ActionDiscard:
+		return "Discard"
 	default:
 		return "Invalid"
 	}
@@ -39,11 +68,11 @@ func (at ActionType) String() string {
 
 func (at ActionType) ToStatus() StatusType {
 	switch at {
-	case ActionCheck, ActionBet, ActionRaise, ActionCall, ActionShowHoleCards, ActionHideHoleCards:
+	case ActionCheck, ActionBet, ActionRaise, ActionCall, ActionShowHoleCards, ActionHideHoleCards, ActionDraw, ActionDiscard:
 		return StatusWaiting
 	case ActionFold:
 		return StatusFolded
-	case ActionAllIn:
+	case ActionAllIn, ActionAcceptRunouts, ActionDeclineRunouts, ActionCashOut, ActionDeclineCashOut:
 		return StatusAllIn
 	default:
 		return StatusReady
@@ -55,4 +84,12 @@ type Action struct {
 
 	// for Bet, Call, Raise
 	Chips int
+
+	// Max is the most chips allowed to Bet or Raise, or the most cards to Draw, zero
+	// for no limit. It is the exact number of cards to Discard.
+	Max int
+
+	// Discard is the indexes of the hole cards to Draw for or to Discard. The
+	// available Discard action suggests the cards discarded on timeout.
+	Discard []int
 }
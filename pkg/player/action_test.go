@@ -9,12 +9,13 @@ func TestActionTypeIntoStatus(t *testing.T) {
 		want       StatusType
 	}{
 		{"Invalid", ActionInvalid, StatusReady},
-		{"Check", ActionCheck, StatusWaitingToAct},
+		{"Check", ActionCheck, StatusWaiting},
 		{"Fold", ActionFold, StatusFolded},
-		{"Bet", ActionBet, StatusWaitingToAct},
-		{"Call", ActionCall, StatusWaitingToAct},
-		{"Raise", ActionRaise, StatusWaitingToAct},
+		{"Bet", ActionBet, StatusWaiting},
+		{"Call", ActionCall, StatusWaiting},
+		{"Raise", ActionRaise, StatusWaiting},
 		{"All-In", ActionAllIn, StatusAllIn},
+		{"AcceptRunouts", ActionAcceptRunouts, StatusAllIn},
 	}
 
 	for _, tc := range testCases {
@@ -40,6 +41,7 @@ func TestActionTypeString(t *testing.T) {
 		{"Call", ActionCall, "Call"},
 		{"Raise", ActionRaise, "Raise"},
 		{"All-In", ActionAllIn, "AllIn"},
+		{"DeclineRunouts", ActionDeclineRunouts, "DeclineRunouts"},
 	}
 
 	for _, tc := range testCases {
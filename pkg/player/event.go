@@ -11,19 +11,44 @@ type EventAction string
 const (
 	EventKind string = "player"
 
-	EventPostSmallBlind EventAction = "PostSmallBlind"
-	EventPostBigBlind   EventAction = "PostBigBlind"
-	EventCheck          EventAction = "Check"
-	EventFold           EventAction = "Fold"
-	EventBet            EventAction = "Bet"
-	EventCall           EventAction = "Call"
-	EventRaise          EventAction = "Raise"
-	EventAllIn          EventAction = "AllIn"
+	EventPostAnte           EventAction = "PostAnte"
+	EventPostBigBlindAnte   EventAction = "PostBigBlindAnte"
+	EventPostButtonAnte     EventAction = "PostButtonAnte"
+	EventPostSmallBlind     EventAction = "PostSmallBlind"
+	EventPostBigBlind       EventAction = "PostBigBlind"
+	EventPostStraddle       EventAction = "PostStraddle"
+	EventPostDeadSmallBlind EventAction = "PostDeadSmallBlind"
+	EventPostMissedBigBlind EventAction = "PostMissedBigBlind"
+	EventPostBringIn        EventAction = "PostBringIn"
+	EventDraw               EventAction = "Draw"
+	EventDiscard            EventAction = "Discard"
+	EventCheck              EventAction = "Check"
+	EventFold               EventAction = "Fold"
+	EventBet                EventAction = "Bet"
+	EventCall               EventAction = "Call"
+	EventRaise              EventAction = "Raise"
+	EventAllIn              EventAction = "AllIn"
+	EventSitOut             EventAction = "SitOut"
+	EventSitIn              EventAction = "SitIn"
+	EventAutoFold           EventAction = "AutoFold"
+	EventRemoved            EventAction = "Removed"
+	EventClockStart         EventAction = "ClockStart"
+	EventTimeBank           EventAction = "TimeBank"
+	EventClockExpired       EventAction = "ClockExpired"
 )
 
 type EventObject struct {
 	ID  string
 	Bet int
+
+	// Timeout is the time left to act, for clock events
+	Timeout time.Duration
+
+	// Draw is the number of cards drawn, for draw events
+	Draw int
+
+	// Discard is the number of cards discarded, for discard events
+	Discard int
 }
 
 type Event struct {
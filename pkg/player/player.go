@@ -33,11 +33,27 @@ type Player struct {
 	// machine readable identity
 	id            string
 	actionTimeout time.Duration
-	holeCards     [2]*card.Card
+	holeCards     []*card.Card
 	chips         int
 	watcher       watch.Interface
 	status        StatusType
 
+	// timeBank is the extra time spent once the base action clock runs out.
+	timeBank time.Duration
+
+	// sittingOut indicates the player sits out from the following hands.
+	sittingOut bool
+	// autoPostBlinds makes the player post missed blinds right after sitting in,
+	// instead of waiting for the big blind.
+	autoPostBlinds bool
+	// timeouts counts the consecutive decisions the player has timed out on.
+	timeouts int
+	// autoFold is the number of consecutive timeouts after which the player folds
+	// (or checks) at once without waiting, zero disables it.
+	autoFold int
+	// timeoutPolicy picks the action taken on behalf of the player on timeout.
+	timeoutPolicy TimeoutPolicy
+
 	// for action handling
 	once sync.Once
 
@@ -68,6 +84,9 @@ func New(opts ...Option) *Player {
 	if p.chips == 0 {
 		p.chips = defaultChips
 	}
+	if p.timeoutPolicy == nil {
+		p.timeoutPolicy = CheckOrFold
+	}
 	return p
 }
 
@@ -103,6 +122,30 @@ func WithActionTimeout(timeout time.Duration) Option {
 	}
 }
 
+func WithTimeBank(timeBank time.Duration) Option {
+	return func(p *Player) {
+		p.timeBank = timeBank
+	}
+}
+
+func WithAutoPostBlinds(autoPost bool) Option {
+	return func(p *Player) {
+		p.autoPostBlinds = autoPost
+	}
+}
+
+func WithTimeoutPolicy(policy TimeoutPolicy) Option {
+	return func(p *Player) {
+		p.timeoutPolicy = policy
+	}
+}
+
+func WithAutoFold(timeouts int) Option {
+	return func(p *Player) {
+		p.autoFold = timeouts
+	}
+}
+
 func WithWatcher(watcher watch.Interface) Option {
 	return func(p *Player) {
 		p.watcher = watcher
@@ -168,6 +211,30 @@ func (p *Player) HideHoleCards(ctx context.Context) error {
 	return p.takeAction(ctx, Action{Type: ActionHideHoleCards})
 }
 
+// Post takes a compulsory bet (blind, ante or straddle) from the player's chips
+// without going through the action channel. A short-stacked player posts all
+// remaining chips, the actually posted amount is returned.
+func (p *Player) Post(chips int) int {
+	if chips < 0 {
+		chips = 0
+	}
+	if chips > p.chips {
+		chips = p.chips
+	}
+	p.chips -= chips
+	if p.status == StatusWaiting && p.chips == 0 {
+		p.status = StatusAllIn
+	}
+	return chips
+}
+
+// AddChips adds bought chips to the stack, it must not be called during a hand.
+func (p *Player) AddChips(chips int) {
+	if chips > 0 {
+		p.chips += chips
+	}
+}
+
 func (p *Player) Ready() error {
 	if p.status != StatusIdle {
 		return fmt.Errorf("player is not idle, cannot ready")
@@ -183,7 +250,10 @@ func (p *Player) Reset() error {
 	p.activeChan = make(chan []Action, 1)
 	p.actionChan = make(chan Action)
 	p.status = StatusReady
-	p.holeCards = [2]*card.Card{}
+	if p.sittingOut {
+		p.status = StatusSittingOut
+	}
+	p.holeCards = nil
 	return nil
 }
 
@@ -197,6 +267,82 @@ func (p *Player) CancelReady() error {
 	return nil
 }
 
+// SitOut takes the player out of the following hands, keeping the seat and chips.
+// If the player is in a hand, it takes effect once the hand ends.
+func (p *Player) SitOut() error {
+	if p.sittingOut {
+		return fmt.Errorf("player is sitting out already")
+	}
+	p.sittingOut = true
+	if p.status == StatusIdle || p.status == StatusReady {
+		p.status = StatusSittingOut
+	}
+	return nil
+}
+
+// DealIn readies the player sitting out to be dealt the next hand, as sit & go
+// tables blind away the stacks of the players who are away. The player sits out
+// again once the hand ends.
+func (p *Player) DealIn() error {
+	if p.status != StatusSittingOut {
+		return fmt.Errorf("player is not sitting out, cannot deal in")
+	}
+	p.status = StatusReady
+	return nil
+}
+
+// SitIn brings the player back to the following hands.
+func (p *Player) SitIn() error {
+	if !p.sittingOut {
+		return fmt.Errorf("player is not sitting out, cannot sit in")
+	}
+	p.sittingOut = false
+	p.timeouts = 0
+	if p.status != StatusSittingOut {
+		// still in the hand, it has not been sat out yet
+		return nil
+	}
+	p.status = StatusIdle
+	return p.Ready()
+}
+
+func (p *Player) SittingOut() bool {
+	return p.sittingOut
+}
+
+func (p *Player) AutoPostBlinds() bool {
+	return p.autoPostBlinds
+}
+
+func (p *Player) SetAutoPostBlinds(autoPost bool) {
+	p.autoPostBlinds = autoPost
+}
+
+func (p *Player) TimeBank() time.Duration {
+	return p.timeBank
+}
+
+// SpendTimeBank takes the time spent beyond the base action clock from the time bank.
+func (p *Player) SpendTimeBank(d time.Duration) {
+	p.timeBank -= d
+	if p.timeBank < 0 {
+		p.timeBank = 0
+	}
+}
+
+// AddTimeBank replenishes the time bank, up to max if max is positive.
+func (p *Player) AddTimeBank(d, max time.Duration) {
+	p.timeBank += d
+	if max > 0 && p.timeBank > max {
+		p.timeBank = max
+	}
+}
+
+// Timeouts returns the number of consecutive decisions the player has timed out on.
+func (p *Player) Timeouts() int {
+	return p.timeouts
+}
+
 // Gone is used to release resources.
 func (p *Player) Gone() error {
 	p.once.Do(func() {
@@ -218,13 +364,23 @@ func (p *Player) ID() string {
 	return p.id
 }
 
-func (p *Player) HoleCards() [2]*card.Card {
+func (p *Player) HoleCards() []*card.Card {
 	return p.holeCards
 }
 
-func (p *Player) SetHoleCards(cards [2]*card.Card) error {
+// SetHoleCards deals the cards to a ready player, who waits to act from then on, or
+// is all-in having posted all the chips. The players in the hand are dealt more
+// cards, or draw, later on.
+func (p *Player) SetHoleCards(cards []*card.Card) error {
 	p.holeCards = cards
-	if p.status != StatusReady {
+	switch p.status {
+	case StatusReady:
+		p.status = StatusWaiting
+		if p.chips == 0 {
+			p.status = StatusAllIn
+		}
+	case StatusWaiting, StatusAllIn:
+	default:
 		return fmt.Errorf("player is not ready, cannot wait to act")
 	}
 	return nil
@@ -246,39 +402,7 @@ func (p *Player) takeAction(ctx context.Context, action Action) error {
 	ctx, cancel := context.WithTimeoutCause(ctx, p.actionTimeout, fmt.Errorf("action timeout"))
 	defer cancel()
 
-	// if p.availableActions == nil {
-	// 	return fmt.Errorf("not have available actions")
-	// }
-	// require, ok := p.availableActions[action.Type]
-	// if !ok {
-	// 	return fmt.Errorf("not available action: %v, available action: %v", action, p.availableActions)
-	// }
-
-	switch action.Type {
-	case ActionCheck, ActionFold:
-	case ActionBet, ActionRaise:
-		// Equivalent to: !(require.Chips <= action.Chips <= p.chips)
-		if require.Chips > action.Chips || action.Chips > p.chips {
-			return fmt.Errorf("not enough chips: %d, can not take the action: %v", p.chips, action)
-		}
-		p.chips -= action.Chips
-	case ActionCall:
-		// Equivalent to: !(require.Chips <= p.chips)
-		if require.Chips > p.chips {
-			return fmt.Errorf("not enough chips: %d, can not take the action: %v", p.chips, action)
-		}
-		action.Chips = require.Chips
-		p.chips -= require.Chips
-	case ActionAllIn:
-		if p.chips <= 0 {
-			return fmt.Errorf("not enough chips: %d", p.chips)
-		}
-		action.Chips = p.chips
-		p.chips = 0
-	default:
-		return fmt.Errorf("invalid action type: %v", action.Type)
-	}
-
+	// the action is verified against the available actions in WaitForAction
 	select {
 	case p.actionChan <- action:
 		return nil
@@ -287,17 +411,20 @@ func (p *Player) takeAction(ctx context.Context, action Action) error {
 	}
 }
 
-// WaitForAction wait for the player to take action
-// action[0] is the default action.
-// TODO(@yshngg): check correct of function call params
+// WaitForAction wait for the player to take action until ctx is done,
+// or the action timeout if ctx has no deadline. Invalid actions are ignored.
+// When time is out, the action picked by the timeout policy is taken instead.
 func (p *Player) WaitForAction(ctx context.Context, available []Action) (*Action, error) {
-	ctx, cancel := context.WithTimeoutCause(ctx, p.actionTimeout, fmt.Errorf("action timeout"))
-	defer cancel()
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, p.actionTimeout, fmt.Errorf("action timeout"))
+		defer cancel()
+	}
 
-	if p.status != StatusWaiting {
+	// all-in players are only asked whether to run the board several times or to cash out
+	if p.status != StatusWaiting && p.status != StatusAllIn {
 		return nil, fmt.Errorf("player %s [id: %s] does not wait to act, status: %s", p.name, p.id, p.status)
 	}
-	// p.status = StatusTakingAction
 
 	// drain active and action channels
 Drain:
@@ -314,35 +441,86 @@ Drain:
 
 	p.activeChan <- available
 
-	// p.availableActions = availableActions
-	// defer func() {
-	// p.availableActions = nil
-	// }()
+	if p.autoFold > 0 && p.timeouts >= p.autoFold {
+		// timed out repeatedly, take the default action without waiting
+		p.inactive()
+		return p.takeDefaultAction(available)
+	}
 
-	action := available[0]
-	select {
-	case <-ctx.Done():
-		<-p.activeChan
-		err := p.takeAction(ctx, action) // default action when timeout
+	var action Action
+	for {
+		select {
+		case <-ctx.Done():
+			p.inactive()
+			p.timeouts++
+			return p.takeDefaultAction(available)
+		case action = <-p.actionChan:
+		}
+		verified, err := p.verifyAction(action, available)
 		if err != nil {
-			return nil, fmt.Errorf("take default action: %v, err: %W", action.Type, err)
+			// TODO(@yshngg): tell the player why the action is ignored
+			continue
 		}
-		return &action, nil
-	case action = <-p.actionChan:
+		action = verified
+		break
+	}
+
+	p.timeouts = 0
+	p.apply(action)
+	p.status = p.statusAfter(action)
+	return &action, nil
+}
 
+// inactive withdraws the available actions, if the player has not received them yet.
+func (p *Player) inactive() {
+	select {
+	case <-p.activeChan:
+	default:
 	}
+}
 
-	// action concluded
-	// if p.status != StatusTakingAction {
-	// 	return nil, fmt.Errorf("player is not take action")
-	// }
-	p.status = action.Type.ToStatus()
+// takeDefaultAction takes the action picked by the timeout policy on behalf of the player.
+func (p *Player) takeDefaultAction(available []Action) (*Action, error) {
+	action, err := p.timeoutPolicy(available)
+	if err != nil {
+		return nil, fmt.Errorf("take default action, err: %w", err)
+	}
+	action, err = p.verifyAction(action, available)
+	if err != nil {
+		return nil, fmt.Errorf("take default action, err: %w", err)
+	}
+	p.apply(action)
+	p.status = p.statusAfter(action)
 	return &action, nil
 }
 
-func (p *Player) verifyAction(action Action, available []Action) error {
+// statusAfter returns the status of the player after the action, drawing and
+// discarding do not change it, all-in players draw and discard too. Calling with
+// all the chips is all-in.
+func (p *Player) statusAfter(action Action) StatusType {
+	if action.Type == ActionDraw || action.Type == ActionDiscard {
+		return p.status
+	}
+	status := action.Type.ToStatus()
+	if status == StatusWaiting && p.chips == 0 {
+		return StatusAllIn
+	}
+	return status
+}
+
+// apply takes the chips of a verified action from the player.
+func (p *Player) apply(action Action) {
+	switch action.Type {
+	case ActionBet, ActionRaise, ActionCall, ActionAllIn:
+		p.chips -= action.Chips
+	}
+}
+
+// verifyAction checks the action against the available actions,
+// and fills in the chips of call and all-in.
+func (p *Player) verifyAction(action Action, available []Action) (Action, error) {
 	if available == nil {
-		return fmt.Errorf("do not have available actions")
+		return action, fmt.Errorf("do not have available actions")
 	}
 	// deduplicate actions
 	availableMap := make(map[ActionType]Action)
@@ -352,31 +530,49 @@ func (p *Player) verifyAction(action Action, available []Action) error {
 
 	require, ok := availableMap[action.Type]
 	if !ok {
-		return fmt.Errorf("action %v invalid, available actions are: %v", action.Type, available)
+		return action, fmt.Errorf("action %v invalid, available actions are: %v", action.Type, available)
 	}
 
 	switch action.Type {
-	case ActionCheck, ActionFold:
+	case ActionCheck, ActionFold, ActionShowHoleCards, ActionHideHoleCards, ActionAcceptRunouts, ActionDeclineRunouts, ActionDeclineCashOut:
+	case ActionCashOut:
+		// the price is offered, not asked
+		action.Chips = require.Chips
 	case ActionBet, ActionRaise:
 		// Equivalent to: !(require.Chips <= action.Chips <= p.chips)
 		if require.Chips > action.Chips || action.Chips > p.chips {
-			return fmt.Errorf("not enough chips: %d, can not take the action: %v", p.chips, action)
+			return action, fmt.Errorf("not enough chips: %d, can not take the action: %v", p.chips, action)
+		}
+		if require.Max > 0 && action.Chips > require.Max {
+			return action, fmt.Errorf("over the limit: %d, can not take the action: %v", require.Max, action)
 		}
-		p.chips -= action.Chips
 	case ActionCall:
 		// Equivalent to: !(require.Chips <= p.chips)
 		if require.Chips > p.chips {
-			return fmt.Errorf("not enough chips: %d, can not take the action: %v", p.chips, action)
+			return action, fmt.Errorf("not enough chips: %d, can not take the action: %v", p.chips, action)
 		}
 		action.Chips = require.Chips
-		p.chips -= require.Chips
 	case ActionAllIn:
 		if p.chips <= 0 {
-			return fmt.Errorf("not enough chips: %d", p.chips)
+			return action, fmt.Errorf("not enough chips: %d", p.chips)
 		}
 		action.Chips = p.chips
-		p.chips = 0
+	case ActionDraw, ActionDiscard:
+		if require.Max > 0 && len(action.Discard) > require.Max {
+			return action, fmt.Errorf("over the limit: %d, can not take the action: %v", require.Max, action)
+		}
+		if action.Type == ActionDiscard && len(action.Discard) != require.Max {
+			return action, fmt.Errorf("discard %d cards, can not take the action: %v", require.Max, action)
+		}
+		discarded := make(map[int]bool, len(action.Discard))
+		for _, i := range action.Discard {
+			if i < 0 || i >= len(p.holeCards) || discarded[i] {
+				return action, fmt.Errorf("invalid discard: %d, can not take the action: %v", i, action)
+			}
+			discarded[i] = true
+		}
 	default:
-		return fmt.Errorf("invalid action type: %v", action.Type)
+		return action, fmt.Errorf("invalid action type: %v", action.Type)
 	}
+	return action, nil
 }
@@ -2,6 +2,7 @@ package player
 
 import (
 	"testing"
+	"time"
 
 	"github.com/yshngg/holdem/pkg/watch"
 	"golang.org/x/sync/errgroup"
@@ -15,18 +16,18 @@ func TestWatch(t *testing.T) {
 	}
 	player := New(WithWatcher(watcher))
 	events := []watch.Event{
-		{Type: EventCheck, Object: nil},
-		{Type: EventFold, Object: nil},
-		{Type: EventBet, Object: nil},
-		{Type: EventCall, Object: nil},
-		{Type: EventRaise, Object: nil},
-		{Type: EventAllIn, Object: nil},
+		NewEvent(EventCheck, EventObject{}),
+		NewEvent(EventFold, EventObject{}),
+		NewEvent(EventBet, EventObject{}),
+		NewEvent(EventCall, EventObject{}),
+		NewEvent(EventRaise, EventObject{}),
+		NewEvent(EventAllIn, EventObject{}),
 	}
 
 	g := new(errgroup.Group)
 	g.Go(func() error {
 		for _, event := range events {
-			err := broadcaster.Action(event.Type, event.Type)
+			err := broadcaster.Action(event)
 			if err != nil {
 				return err
 			}
@@ -34,10 +35,11 @@ func TestWatch(t *testing.T) {
 		return nil
 	})
 
+	out := player.Watch()
 	for _, want := range events {
-		got := <-player.Watch()
-		if got.Type != want.Type {
-			t.Errorf("event type: %v, want: %v", got.Type, want.Type)
+		got := <-out
+		if got.Action() != want.Action() {
+			t.Errorf("event action: %v, want: %v", got.Action(), want.Action())
 		}
 	}
 
@@ -48,14 +50,20 @@ func TestWatch(t *testing.T) {
 
 func TestAction(t *testing.T) {
 	player := New()
+	if err := player.Ready(); err != nil {
+		t.Fatalf("player ready, err: %v", err)
+	}
+	if err := player.SetHoleCards(nil); err != nil {
+		t.Fatalf("player set hole cards, err: %v", err)
+	}
 	g := errgroup.Group{}
 	g.Go(func() error {
 		err := player.Check(t.Context())
 		return err
 	})
 	action, err := player.WaitForAction(t.Context(), []Action{
-		{ActionCheck, 0},
-		{ActionBet, 2},
+		{Type: ActionCheck},
+		{Type: ActionBet, Chips: 2},
 	})
 	if err != nil {
 		t.Fatalf("player wait for action, err: %v", err)
@@ -68,3 +76,53 @@ func TestAction(t *testing.T) {
 		t.Fatalf("action type: %v, want: %v", action.Type, ActionCheck)
 	}
 }
+
+func TestSitOut(t *testing.T) {
+	player := New()
+	if err := player.Ready(); err != nil {
+		t.Fatalf("player ready, err: %v", err)
+	}
+	if err := player.SitOut(); err != nil {
+		t.Fatalf("player sit out, err: %v", err)
+	}
+	if player.Status() != StatusSittingOut {
+		t.Errorf("status: %v, want: %v", player.Status(), StatusSittingOut)
+	}
+	if err := player.SitOut(); err == nil {
+		t.Errorf("sit out twice, err: nil, want: not nil")
+	}
+
+	// sitting out players stay out after the hand
+	if err := player.Reset(); err != nil {
+		t.Fatalf("player reset, err: %v", err)
+	}
+	if player.Status() != StatusSittingOut {
+		t.Errorf("status: %v, want: %v", player.Status(), StatusSittingOut)
+	}
+
+	if err := player.SitIn(); err != nil {
+		t.Fatalf("player sit in, err: %v", err)
+	}
+	if player.Status() != StatusReady {
+		t.Errorf("status: %v, want: %v", player.Status(), StatusReady)
+	}
+	if err := player.SitIn(); err == nil {
+		t.Errorf("sit in twice, err: nil, want: not nil")
+	}
+}
+
+func TestTimeBank(t *testing.T) {
+	player := New(WithTimeBank(30 * time.Second))
+	player.SpendTimeBank(10 * time.Second)
+	if player.TimeBank() != 20*time.Second {
+		t.Errorf("time bank: %v, want: %v", player.TimeBank(), 20*time.Second)
+	}
+	player.AddTimeBank(20*time.Second, 30*time.Second)
+	if player.TimeBank() != 30*time.Second {
+		t.Errorf("time bank: %v, want: %v", player.TimeBank(), 30*time.Second)
+	}
+	player.SpendTimeBank(time.Minute)
+	if player.TimeBank() != 0 {
+		t.Errorf("time bank: %v, want: %v", player.TimeBank(), 0)
+	}
+}
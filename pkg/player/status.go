@@ -4,8 +4,9 @@ type StatusType int
 
 const (
 	// start status
-	StatusIdle  StatusType = iota
-	StatusReady            // ready to start a new round, wait for dealer to deal hole cards
+	StatusIdle       StatusType = iota
+	StatusReady                 // ready to start a new round, wait for dealer to deal hole cards
+	StatusSittingOut            // keep the seat and chips, but skipped in dealing
 
 	// intermediate status
 	StatusWaiting // wait for next action, after being dealt two hole cards
@@ -22,6 +23,8 @@ func (s StatusType) String() string {
 	switch s {
 	case StatusReady:
 		return "Ready"
+	case StatusSittingOut:
+		return "SittingOut"
 	case StatusWaiting:
 		return "Waiting"
 	// case StatusTakingAction:
@@ -10,10 +10,12 @@ func TestStatusString(t *testing.T) {
 	}{
 		{"Idle", StatusIdle, "Idle"},
 		{"Ready", StatusReady, "Ready"},
-		{"Waiting", StatusWaitingToAct, "Waiting"},
-		{"TakingAction", StatusTakingAction, "TakingAction"},
+		{"SittingOut", StatusSittingOut, "SittingOut"},
+		{"Waiting", StatusWaiting, "Waiting"},
 		{"Folded", StatusFolded, "Folded"},
 		{"AllIn", StatusAllIn, "AllIn"},
+		{"Won", StatusWon, "Won"},
+		{"Lost", StatusLost, "Lost"},
 	}
 
 	for _, tc := range testCases {
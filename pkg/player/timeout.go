@@ -0,0 +1,31 @@
+package player
+
+import "fmt"
+
+// TimeoutPolicy picks the action taken on behalf of a player who has not acted in
+// time. It must not depend on the order of the available actions.
+type TimeoutPolicy func(available []Action) (Action, error)
+
+type ErrNoTimeoutAction struct {
+	Available []Action
+}
+
+func (e ErrNoTimeoutAction) Error() string {
+	return fmt.Sprintf("no action to take on timeout, available: %v", e.Available)
+}
+
+// CheckOrFold checks when it is free and folds otherwise, it never bets, calls or
+// goes all-in on behalf of the player. Outside of the betting rounds, e.g. at
+// showdown, it hides the hole cards, it declines to run the board several times
+// or to cash out, it stands pat in draw games and discards the suggested cards.
+// It returns ErrNoTimeoutAction if none of these is available.
+func CheckOrFold(available []Action) (Action, error) {
+	for _, actionType := range []ActionType{ActionCheck, ActionFold, ActionHideHoleCards, ActionDeclineRunouts, ActionDeclineCashOut, ActionDraw, ActionDiscard} {
+		for _, action := range available {
+			if action.Type == actionType {
+				return action, nil
+			}
+		}
+	}
+	return Action{}, ErrNoTimeoutAction{Available: available}
+}
@@ -0,0 +1,151 @@
+package player
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckOrFold(t *testing.T) {
+	testCases := []struct {
+		name      string
+		available []Action
+		want      ActionType
+	}{
+		{
+			name: "PreFlopFacingBigBlind",
+			available: []Action{
+				{Type: ActionCall, Chips: 2},
+				{Type: ActionRaise, Chips: 4},
+				{Type: ActionAllIn},
+				{Type: ActionFold},
+			},
+			want: ActionFold,
+		},
+		{
+			name: "PreFlopBigBlindOption",
+			available: []Action{
+				{Type: ActionAllIn},
+				{Type: ActionRaise, Chips: 2},
+				{Type: ActionFold},
+				{Type: ActionCheck},
+			},
+			want: ActionCheck,
+		},
+		{
+			name: "FlopNoBet",
+			available: []Action{
+				{Type: ActionBet, Chips: 2},
+				{Type: ActionAllIn},
+				{Type: ActionFold},
+				{Type: ActionCheck},
+			},
+			want: ActionCheck,
+		},
+		{
+			name: "TurnFacingBet",
+			available: []Action{
+				{Type: ActionCall, Chips: 10},
+				{Type: ActionAllIn},
+				{Type: ActionRaise, Chips: 20},
+				{Type: ActionFold},
+			},
+			want: ActionFold,
+		},
+		{
+			name: "RiverFacingAllIn",
+			available: []Action{
+				{Type: ActionAllIn},
+				{Type: ActionFold},
+			},
+			want: ActionFold,
+		},
+		{
+			name: "Showdown",
+			available: []Action{
+				{Type: ActionShowHoleCards},
+				{Type: ActionHideHoleCards},
+			},
+			want: ActionHideHoleCards,
+		},
+		{
+			name: "Runouts",
+			available: []Action{
+				{Type: ActionAcceptRunouts},
+				{Type: ActionDeclineRunouts},
+			},
+			want: ActionDeclineRunouts,
+		},
+		{
+			name: "CashOut",
+			available: []Action{
+				{Type: ActionCashOut, Chips: 120},
+				{Type: ActionDeclineCashOut},
+			},
+			want: ActionDeclineCashOut,
+		},
+		{
+			name:      "Draw",
+			available: []Action{{Type: ActionDraw, Max: 5}},
+			want:      ActionDraw,
+		},
+		{
+			name:      "Discard",
+			available: []Action{{Type: ActionDiscard, Max: 1, Discard: []int{2}}},
+			want:      ActionDiscard,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CheckOrFold(tc.available)
+			if err != nil {
+				t.Fatalf("CheckOrFold(%v), err: %v", tc.available, err)
+			}
+			if got.Type != tc.want {
+				t.Errorf("CheckOrFold(%v) = %v, want %v", tc.available, got.Type, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckOrFoldNoAction(t *testing.T) {
+	available := []Action{{Type: ActionAcceptRunouts}}
+	if _, err := CheckOrFold(available); !errors.As(err, &ErrNoTimeoutAction{}) {
+		t.Errorf("CheckOrFold(%v), err: %v, want: %T", available, err, ErrNoTimeoutAction{})
+	}
+}
+
+func TestWaitForActionTimeout(t *testing.T) {
+	player := New(WithStatus(StatusWaiting), WithChips(100), WithActionTimeout(time.Millisecond))
+	player.activeChan = make(chan []Action, 1)
+	player.actionChan = make(chan Action)
+	action, err := player.WaitForAction(t.Context(), []Action{
+		{Type: ActionCall, Chips: 10},
+		{Type: ActionRaise, Chips: 20},
+		{Type: ActionAllIn},
+		{Type: ActionFold},
+	})
+	if err != nil {
+		t.Fatalf("player wait for action, err: %v", err)
+	}
+	if action.Type != ActionFold {
+		t.Errorf("action type: %v, want: %v", action.Type, ActionFold)
+	}
+	if player.Chips() != 100 {
+		t.Errorf("chips: %d, want: %d", player.Chips(), 100)
+	}
+	if player.Timeouts() != 1 {
+		t.Errorf("timeouts: %d, want: %d", player.Timeouts(), 1)
+	}
+}
+
+func TestWaitForActionTimeoutWithoutFold(t *testing.T) {
+	player := New(WithStatus(StatusAllIn), WithActionTimeout(time.Millisecond))
+	player.activeChan = make(chan []Action, 1)
+	player.actionChan = make(chan Action)
+	_, err := player.WaitForAction(t.Context(), []Action{{Type: ActionAcceptRunouts}})
+	if !errors.As(err, &ErrNoTimeoutAction{}) {
+		t.Errorf("player wait for action, err: %v, want: %T", err, ErrNoTimeoutAction{})
+	}
+}
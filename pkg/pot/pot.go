@@ -3,11 +3,15 @@ package pots
 type Pot interface {
 	Contributors() map[string]struct{}
 	Chips() int
+
+	// Rake returns the chips taken from the pot by the house, not included in Chips.
+	Rake() int
 }
 
 type pot struct {
 	contributors map[string]struct{}
 	chips        int
+	rake         int
 }
 
 var _ Pot = pot{}
@@ -26,3 +30,7 @@ func (p pot) Contributors() map[string]struct{} {
 func (p pot) Chips() int {
 	return p.chips
 }
+
+func (p pot) Rake() int {
+	return p.rake
+}
@@ -0,0 +1,60 @@
+package pots
+
+import (
+	"math"
+	"slices"
+)
+
+// RakePolicy is how the house takes a share of the pots.
+type RakePolicy struct {
+	// Percent is the share of every pot taken, zero means no rake.
+	Percent float64
+
+	// Caps limit the rake of a hand by the number of players dealt in, keyed by the
+	// least number of players they apply to. The rake is not capped if none applies.
+	Caps map[int]int
+
+	// NoFlopNoDrop takes no rake from the hands ending before the flop.
+	NoFlopNoDrop bool
+}
+
+// Cap returns the most rake taken from a hand with the number of players dealt in.
+func (r RakePolicy) Cap(players int) (int, bool) {
+	least := -1
+	for count := range r.Caps {
+		if count <= players && count > least {
+			least = count
+		}
+	}
+	if least < 0 {
+		return 0, false
+	}
+	return r.Caps[least], true
+}
+
+// Apply takes the rake from the settled pots, starting from the main pot until the
+// cap is reached. Pots with a single contributor, such as uncalled bets returned,
+// are not raked. flop reports whether the flop has been dealt.
+func (r RakePolicy) Apply(potList []Pot, players int, flop bool) []Pot {
+	raked := slices.Clone(potList)
+	if r.Percent <= 0 || (r.NoFlopNoDrop && !flop) {
+		return raked
+	}
+	limit, capped := r.Cap(players)
+	for i, p := range raked {
+		if len(p.Contributors()) < 2 {
+			continue
+		}
+		rake := int(math.Floor(float64(p.Chips()) * r.Percent / 100))
+		if capped {
+			rake = min(rake, limit)
+			limit -= rake
+		}
+		raked[i] = pot{
+			contributors: p.Contributors(),
+			chips:        p.Chips() - rake,
+			rake:         p.Rake() + rake,
+		}
+	}
+	return raked
+}
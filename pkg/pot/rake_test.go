@@ -0,0 +1,85 @@
+package pots
+
+import "testing"
+
+func TestRakePolicyApply(t *testing.T) {
+	two := map[string]struct{}{"a": {}, "b": {}}
+	three := map[string]struct{}{"a": {}, "b": {}, "c": {}}
+	one := map[string]struct{}{"c": {}}
+
+	testCases := []struct {
+		name    string
+		policy  RakePolicy
+		pots    []Pot
+		players int
+		flop    bool
+		chips   []int
+		rake    []int
+	}{
+		{
+			name:    "NoRake",
+			policy:  RakePolicy{},
+			pots:    []Pot{pot{contributors: two, chips: 100}},
+			players: 2,
+			flop:    true,
+			chips:   []int{100},
+			rake:    []int{0},
+		},
+		{
+			name:    "Percent",
+			policy:  RakePolicy{Percent: 5},
+			pots:    []Pot{pot{contributors: two, chips: 110}},
+			players: 2,
+			flop:    true,
+			chips:   []int{105},
+			rake:    []int{5},
+		},
+		{
+			name:    "NoFlopNoDrop",
+			policy:  RakePolicy{Percent: 5, NoFlopNoDrop: true},
+			pots:    []Pot{pot{contributors: two, chips: 100}},
+			players: 2,
+			flop:    false,
+			chips:   []int{100},
+			rake:    []int{0},
+		},
+		{
+			name:    "CapAcrossPots",
+			policy:  RakePolicy{Percent: 10, Caps: map[int]int{2: 5, 4: 15}},
+			pots:    []Pot{pot{contributors: three, chips: 120}, pot{contributors: two, chips: 80}},
+			players: 3,
+			flop:    true,
+			chips:   []int{115, 80},
+			rake:    []int{5, 0},
+		},
+		{
+			name:    "CapByPlayerCount",
+			policy:  RakePolicy{Percent: 10, Caps: map[int]int{2: 5, 4: 15}},
+			pots:    []Pot{pot{contributors: three, chips: 120}, pot{contributors: two, chips: 80}},
+			players: 6,
+			flop:    true,
+			chips:   []int{108, 77},
+			rake:    []int{12, 3},
+		},
+		{
+			name:    "UncalledBet",
+			policy:  RakePolicy{Percent: 10},
+			pots:    []Pot{pot{contributors: two, chips: 100}, pot{contributors: one, chips: 50}},
+			players: 3,
+			flop:    true,
+			chips:   []int{90, 50},
+			rake:    []int{10, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.policy.Apply(tc.pots, tc.players, tc.flop)
+			for i, p := range got {
+				if p.Chips() != tc.chips[i] || p.Rake() != tc.rake[i] {
+					t.Errorf("pot %d: (%d, %d), want: (%d, %d)", i, p.Chips(), p.Rake(), tc.chips[i], tc.rake[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package pots
+
+// Split divides the chips of a pot between the best high hands and the best
+// qualifying low hands, both ordered from the left of the button. The high half
+// takes the odd chip, and within a half the odd chips go to the first winners.
+// A player winning both halves, or sharing one, is awarded every share, e.g. the
+// quarter of a low split with another player. If no one qualifies for the low,
+// the high hands scoop the pot.
+func Split(chips int, high, low []string) map[string]int {
+	awards := make(map[string]int, len(high)+len(low))
+	if len(low) == 0 {
+		share(awards, chips, high)
+		return awards
+	}
+	lowHalf := chips / 2
+	share(awards, chips-lowHalf, high)
+	share(awards, lowHalf, low)
+	return awards
+}
+
+// share divides the chips evenly between the winners, the odd chips going to the
+// first winners.
+func share(awards map[string]int, chips int, winners []string) {
+	if len(winners) == 0 {
+		return
+	}
+	for i, id := range winners {
+		awards[id] += chips / len(winners)
+		if i < chips%len(winners) {
+			awards[id]++
+		}
+	}
+}
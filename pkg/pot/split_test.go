@@ -0,0 +1,31 @@
+package pots
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	testCases := []struct {
+		name      string
+		chips     int
+		high, low []string
+		want      map[string]int
+	}{
+		{"NoLow", 101, []string{"a"}, nil, map[string]int{"a": 101}},
+		{"HighAndLow", 101, []string{"a"}, []string{"b"}, map[string]int{"a": 51, "b": 50}},
+		{"Scoop", 100, []string{"a"}, []string{"a"}, map[string]int{"a": 100}},
+		{"Quartered", 100, []string{"a"}, []string{"a", "b"}, map[string]int{"a": 75, "b": 25}},
+		{"OddChips", 103, []string{"a", "b"}, []string{"c", "d"}, map[string]int{"a": 26, "b": 26, "c": 26, "d": 25}},
+		{"SplitHighNoLow", 5, []string{"a", "b"}, []string{}, map[string]int{"a": 3, "b": 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Split(tc.chips, tc.high, tc.low)
+			if !maps.Equal(got, tc.want) {
+				t.Errorf("Split(%d, %v, %v) = %v, want %v", tc.chips, tc.high, tc.low, got, tc.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,9 @@ const (
 	Queen
 	King
 	Ace
+
+	// Joker is the rank of the jokers, wild cards above the aces.
+	Joker
 )
 
 func (r Rank) String() string {
@@ -47,6 +50,8 @@ func (r Rank) String() string {
 		return "King"
 	case Ace:
 		return "Ace"
+	case Joker:
+		return "Joker"
 	default:
 		return "Invalid"
 	}
@@ -0,0 +1,21 @@
+package round
+
+import "time"
+
+// Clock configures how long a player can think about a decision.
+type Clock struct {
+	// Base is the time for every decision, the player's time bank is spent
+	// automatically once it runs out.
+	Base time.Duration
+
+	// Streets overrides the base time on the given streets.
+	Streets map[StatusType]time.Duration
+}
+
+// base returns the base time for a decision on the street.
+func (c Clock) base(street StatusType) time.Duration {
+	if d, ok := c.Streets[street]; ok && d > 0 {
+		return d
+	}
+	return c.Base
+}
@@ -0,0 +1,36 @@
+package round
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockBase(t *testing.T) {
+	clock := Clock{
+		Base: 10 * time.Second,
+		Streets: map[StatusType]time.Duration{
+			StatusPreFlop: 5 * time.Second,
+			StatusRiver:   20 * time.Second,
+			StatusTurn:    0,
+		},
+	}
+	testCases := []struct {
+		name   string
+		street StatusType
+		want   time.Duration
+	}{
+		{"PreFlop", StatusPreFlop, 5 * time.Second},
+		{"Flop", StatusFlop, 10 * time.Second},
+		{"Turn", StatusTurn, 10 * time.Second},
+		{"River", StatusRiver, 20 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := clock.base(tc.street)
+			if got != tc.want {
+				t.Errorf("base time: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
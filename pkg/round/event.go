@@ -1,10 +1,12 @@
 package round
 
 import (
+	"slices"
 	"time"
 
 	"github.com/yshngg/holdem/pkg/card"
 	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/pot"
 	"github.com/yshngg/holdem/pkg/watch"
 )
 
@@ -18,7 +20,10 @@ const (
 	EventFlop     EventAction = "Flop"
 	EventTurn     EventAction = "Turn"
 	EventRiver    EventAction = "River"
+	EventCashOut  EventAction = "CashOut"
+	EventRunout   EventAction = "Runout"
 	EventShowdown EventAction = "Showdown"
+	EventRake     EventAction = "Rake"
 	EventEnd      EventAction = "End"
 )
 
@@ -29,9 +34,23 @@ type PlayerInfo struct {
 	Status player.StatusType
 }
 
+type PotInfo struct {
+	Contributors []string
+	Chips        int
+	Rake         int
+}
+
+// CashOutInfo is the chips paid to an all-in player who has cashed out the equity.
+type CashOutInfo struct {
+	ID    string
+	Chips int
+}
+
 type EventObject struct {
 	Players        []PlayerInfo
 	CommunityCards []*card.Card
+	Pots           []PotInfo
+	CashOuts       []CashOutInfo
 }
 
 type Event struct {
@@ -60,6 +79,37 @@ func NewEvent(action EventAction, players []*player.Player, communityCards ...*c
 	}
 }
 
+// NewPotsEvent instances a new round Event about the settled pots.
+func NewPotsEvent(action EventAction, potList []pots.Pot) watch.Event {
+	infos := make([]PotInfo, 0, len(potList))
+	for _, pot := range potList {
+		contributors := make([]string, 0, len(pot.Contributors()))
+		for id := range pot.Contributors() {
+			contributors = append(contributors, id)
+		}
+		slices.Sort(contributors)
+		infos = append(infos, PotInfo{
+			Contributors: contributors,
+			Chips:        pot.Chips(),
+			Rake:         pot.Rake(),
+		})
+	}
+	return Event{
+		action:    action,
+		object:    EventObject{Pots: infos},
+		eventTime: time.Now(),
+	}
+}
+
+// NewCashOutEvent instances a new round Event about the equities cashed out.
+func NewCashOutEvent(action EventAction, cashOuts []CashOutInfo) watch.Event {
+	return Event{
+		action:    action,
+		object:    EventObject{CashOuts: cashOuts},
+		eventTime: time.Now(),
+	}
+}
+
 func (e Event) Kind() string {
 	return EventKind
 }
@@ -0,0 +1,104 @@
+package round
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/hand"
+	"github.com/yshngg/holdem/pkg/ledger"
+	"github.com/yshngg/holdem/pkg/player"
+)
+
+// Insurance offers the all-in players to cash out their equity in the pots before
+// the runout. The insurer pays the price offered right away, and collects whatever
+// the player would have won instead.
+type Insurance struct {
+	// Margin is the percentage of the equity the insurer keeps off the price.
+	Margin float64
+}
+
+// WithInsurance offers all-in insurance once the players are all-in before the river.
+func WithInsurance(insurance Insurance) Option {
+	return func(r *Round) {
+		r.insurance = &insurance
+	}
+}
+
+// offerCashOuts offers every all-in player, from the left of the button, to cash out
+// the equity in the pots contested on the remaining deck, less the margin.
+func (r *Round) offerCashOuts(ctx context.Context) error {
+	board := values(r.communityCards)
+	remaining := r.dealer.Remaining()
+	expected := make(map[string]float64)
+	// the flop is always dealt by the runout
+	for _, pot := range r.rake.Apply(r.pots.Settle(), len(r.players), true) {
+		contenders := r.contenders(pot)
+		if len(contenders) < 2 {
+			// returned to the only contender, nothing to insure
+			continue
+		}
+		hands := make([][]card.Card, 0, len(contenders))
+		for _, p := range contenders {
+			hands = append(hands, r.cardsOf(p))
+		}
+		equities, err := hand.Equity(r.variant.Evaluator(), r.variant.Low(), hands, board, remaining)
+		if err != nil {
+			return fmt.Errorf("compute equity, err: %w", err)
+		}
+		for i, p := range contenders {
+			expected[p.ID()] += equities[i] * float64(pot.Chips())
+		}
+	}
+
+	cashOuts := make([]CashOutInfo, 0)
+	for i := range len(r.position) {
+		p, ok := r.players[r.position[(r.button+i+1)%len(r.position)]]
+		if !ok || p.Status() != player.StatusAllIn {
+			continue
+		}
+		price := int(math.Floor(expected[p.ID()] * (100 - r.insurance.Margin) / 100))
+		if price <= 0 {
+			continue
+		}
+		available := []player.Action{
+			{Type: player.ActionCashOut, Chips: price},
+			{Type: player.ActionDeclineCashOut},
+		}
+		action, err := r.waitForAction(ctx, p, available)
+		if err != nil {
+			return fmt.Errorf("wait for player (id: %s) to take action, err: %w", p.ID(), err)
+		}
+		if action.Type != player.ActionCashOut {
+			continue
+		}
+		r.insured[p.ID()] = action.Chips
+		p.AddChips(action.Chips)
+		if err := r.record(ledger.Insurance, ledger.Insurer, ledger.PlayerAccount(p.ID()), action.Chips); err != nil {
+			return err
+		}
+		cashOuts = append(cashOuts, CashOutInfo{ID: p.ID(), Chips: action.Chips})
+	}
+	if len(cashOuts) == 0 {
+		return nil
+	}
+	cashOutEvent := NewCashOutEvent(EventCashOut, cashOuts)
+	if err := r.broadcaster.Action(cashOutEvent); err != nil {
+		return fmt.Errorf("broadcast event: %v, err: %w", cashOutEvent, err)
+	}
+	return nil
+}
+
+// Insured returns the chips paid to the players who have cashed out their equity.
+func (r *Round) Insured() map[string]int {
+	return r.insured
+}
+
+func values(cards []*card.Card) []card.Card {
+	result := make([]card.Card, 0, len(cards))
+	for _, c := range cards {
+		result = append(result, *c)
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package round
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/ledger"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+// shoving goes all-in once free to bet after the flop, checks until then, and
+// cashes out.
+func shoving(available []player.Action) (player.Action, error) {
+	if slices.ContainsFunc(available, func(action player.Action) bool { return action.Type == player.ActionBet }) {
+		return prefer(player.ActionAllIn)(available)
+	}
+	return prefer(player.ActionCheck, player.ActionCashOut)(available)
+}
+
+func TestInsurance(t *testing.T) {
+	// aces against kings all-in on a flop of eights and a jack
+	deck := func(turn card.Card) []card.Card {
+		return []card.Card{
+			card.New(rank.Ace, suit.Spades), card.New(rank.King, suit.Spades),
+			card.New(rank.Ace, suit.Hearts), card.New(rank.King, suit.Hearts),
+			card.New(rank.Five, suit.Spades), // burn
+			card.New(rank.Eight, suit.Diamonds), card.New(rank.Eight, suit.Clubs), card.New(rank.Jack, suit.Hearts),
+			card.New(rank.Five, suit.Hearts), // burn
+			turn,
+			card.New(rank.Five, suit.Diamonds), // burn
+			card.New(rank.Six, suit.Hearts),
+		}
+	}
+	testCases := []struct {
+		name string
+		deck []card.Card
+		// won is the pot the aces win, collected by the insurer
+		won int
+	}{
+		{"Held", deck(card.New(rank.Queen, suit.Clubs)), 200},
+		{"Lost", deck(card.New(rank.King, suit.Diamonds)), 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			players := []*player.Player{
+				bot("p0", 100, prefer(player.ActionCall, player.ActionDeclineCashOut)),
+				bot("p1", 100, shoving),
+			}
+			r := deal(t, players, 0, WithInsurance(Insurance{Margin: 10}), WithDeck(stacked(tc.deck...)))
+
+			// the kings catch up on 87 of the 990 runouts, the aces cash out their
+			// 200 by 903/990 less the margin, the kings decline
+			price, ok := r.Insured()["p1"]
+			if !ok || price != 164 {
+				t.Fatalf("insured: %v, want the aces paid 164", r.Insured())
+			}
+			if _, ok := r.Insured()["p0"]; ok {
+				t.Errorf("insured: %v, want the kings to have declined", r.Insured())
+			}
+			if got := r.ledger.Balance(ledger.PlayerAccount("p1")); got != price {
+				t.Errorf("balance of p1: %d, want the price of %d", got, price)
+			}
+			if got, want := r.ledger.Balance(ledger.PlayerAccount("p0")), 200-tc.won; got != want {
+				t.Errorf("balance of p0: %d, want: %d", got, want)
+			}
+			if got, want := r.ledger.Balance(ledger.Insurer), tc.won-price; got != want {
+				t.Errorf("balance of the insurer: %d, want: %d", got, want)
+			}
+		})
+	}
+}
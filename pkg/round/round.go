@@ -4,17 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/yshngg/holdem/pkg/card"
 	"github.com/yshngg/holdem/pkg/dealer"
+	"github.com/yshngg/holdem/pkg/deck"
+	"github.com/yshngg/holdem/pkg/game"
+	"github.com/yshngg/holdem/pkg/ledger"
 	"github.com/yshngg/holdem/pkg/player"
 	pots "github.com/yshngg/holdem/pkg/pot"
+	"github.com/yshngg/holdem/pkg/variant"
 	"github.com/yshngg/holdem/pkg/watch"
 )
 
 const (
-	defaultMinBet = 2
-	defaultButton = 0
+	defaultMinBet        = 2
+	defaultButton        = 0
+	defaultActionTimeout = 5 * time.Second
 
 	MinPlayerCount = 2
 	MaxPlayerCount = 22
@@ -27,7 +33,14 @@ type playerCount struct {
 type Round struct {
 	number int
 
+	// variant is the game dealt, hold'em by default.
+	variant variant.Variant
+
+	// limit is the betting structure, the default one of the variant if not given.
+	limit variant.Limit
+
 	// position indicates the position relationship among players in round.
+	// position[i] is the id of player sitting at seat i, empty if the seat is not in round.
 	position []string
 
 	// players only includes players in round.
@@ -37,6 +50,9 @@ type Round struct {
 	// Responsible for dealing hole cards to players and community cards to the table.
 	dealer *dealer.Dealer
 
+	// deck is dealt in order instead of a fresh shuffled deck of the variant.
+	deck *deck.Deck
+
 	// button is the position of the dealer button in the round.
 	// The player immediately to the left of the button posts the small blind.
 	button int
@@ -45,18 +61,93 @@ type Round struct {
 	// Typically equals the big blind amount (double the small blind).
 	minBet int
 
+	// smallBlind and bigBlind are the compulsory bets posted by the two players
+	// to the left of the button. By default the big blind equals minBet and the
+	// small blind is half of it.
+	smallBlind, bigBlind int
+
+	// ante is a dead bet posted by every player before the hand starts.
+	ante int
+
+	// bigBlindAnte is a dead bet posted by the big blind on behalf of the whole table,
+	// it replaces the per player ante.
+	bigBlindAnte int
+
+	// buttonAnte is a dead bet posted by the button on behalf of the whole table,
+	// it replaces the per player ante, as in short deck.
+	buttonAnte int
+
+	// bringIn is the forced bet of the lowest up card in stud, which replaces the blinds.
+	bringIn int
+
+	// straddle indicates who posts an optional straddle (twice the big blind).
+	straddle Straddle
+
+	// smallBlindSeat and bigBlindSeat are given by the table when it applies the dead
+	// button rule, a negative small blind seat means a dead small blind. If they are
+	// not given, the blinds are derived from the button.
+	smallBlindSeat, bigBlindSeat int
+
+	// missed holds the blinds that returning players have missed and have to post.
+	// A missed big blind is live, a missed small blind is dead.
+	missed map[string]MissedBlinds
+
+	// clock is the action clock, players spend their time banks once it runs out.
+	clock Clock
+
+	// blinds records the live compulsory bets (blinds and straddle) of the hand,
+	// they count toward the player's bet in the pre-flop betting round.
+	blinds map[string]int
+
 	// communityCards are the shared cards visible to all players.
 	// The length progresses through 0 (pre-flop), 3 (flop), 4 (turn), and 5 (river).
 	communityCards []*card.Card
 
+	// upCards are the cards dealt face up to the players, as in stud.
+	upCards map[string][]*card.Card
+
+	// muck holds the cards discarded in draw games, reshuffled once the deck runs out.
+	muck []card.Card
+
+	// street is the index of the street played, in the streets of the variant.
+	street int
+
+	// firstToAct is the seat to act first on the street when the variant decides it,
+	// such as after the bring-in in stud, negative to follow the blinds and the button.
+	firstToAct int
+
 	// pots holds all active pots in the round, including the main pot and side pots.
 	// pots[0] is always the main pot; subsequent elements are side pots (if any).
 	pots pots.Pots
 
+	// rake is the policy the house takes a share of the pots by.
+	rake pots.RakePolicy
+
+	// raked is the rake taken from every settled pot, from the main pot.
+	raked []int
+
+	// ledger records every chip moved in the hand.
+	ledger *ledger.Ledger
+
+	// insurance offers the all-in players to cash out their equity before the runout,
+	// insured holds the chips paid to those who have.
+	insurance *Insurance
+	insured   map[string]int
+
+	// runouts is the most times the rest of the board is run once the players are all-in.
+	runouts int
+
+	// flopPlayers is the number of players who have seen the flop.
+	flopPlayers int
+
 	// recorder captures all game events for replay, debugging, or auditing purposes.
 	// It logs actions like bets, folds, and card deals.
 	recorder watch.Recorder
 
+	// ownRecorder indicates the recorder is created by the round, and stopped once
+	// the round ends.
+	ownRecorder bool
+
 	// broadcaster delivers real-time game events to all connected players.
 	// Ensures players receive synchronized updates about round state changes.
 	broadcaster watch.Broadcaster
@@ -70,19 +161,16 @@ type Round struct {
 
 // New init a new round, button must greater than or equal to zero.
 func New(players []*player.Player, button int, opts ...Option) *Round {
-	if button <= 0 {
+	if button < 0 {
 		panic(errors.New("MUST button >= 0"))
 	}
-	counter := button
-	position := make([]string, 0)
+	position := make([]string, 0, len(players))
 	playersMap := make(map[string]*player.Player)
 
-	// TODO(@yshngg):
-	for i, p := range players {
-		if counter != 0 {
-			counter--
-		}
+	for _, p := range players {
 		if p == nil {
+			// keep the empty seat, so positions in round match seats on the table
+			position = append(position, "")
 			continue
 		}
 		position = append(position, p.ID())
@@ -93,7 +181,16 @@ func New(players []*player.Player, button int, opts ...Option) *Round {
 		players:  playersMap,
 		button:   button,
 		minBet:   -1,
+		limit:    -1,
+		pots:     pots.New(),
+		insured:  make(map[string]int),
+		upCards:  make(map[string][]*card.Card),
 		status:   StatusReady,
+
+		firstToAct: -1,
+
+		smallBlindSeat: -1,
+		bigBlindSeat:   -1,
 	}
 	for _, opt := range opts {
 		opt(r)
@@ -105,8 +202,29 @@ func New(players []*player.Player, button int, opts ...Option) *Round {
 	if r.button < 0 {
 		r.button = defaultButton
 	}
+	if r.variant == nil {
+		r.variant = variant.Holdem
+	}
+	if r.limit < 0 {
+		r.limit = r.variant.Limit()
+	}
 	if r.minBet < 0 {
 		r.minBet = defaultMinBet
+		if r.bigBlind > 0 {
+			r.minBet = r.bigBlind
+		}
+	}
+	if r.bigBlind <= 0 {
+		r.bigBlind = r.minBet
+	}
+	if r.smallBlind <= 0 {
+		r.smallBlind = r.bigBlind / 2
+	}
+	if r.bringIn <= 0 {
+		r.bringIn = r.smallBlind
+	}
+	if r.clock.Base <= 0 {
+		r.clock.Base = defaultActionTimeout
 	}
 	if r.broadcaster == nil {
 		queueLength := len(players) * 2
@@ -119,13 +237,20 @@ func New(players []*player.Player, button int, opts ...Option) *Round {
 			panic(err)
 		}
 		r.recorder = watch.NewRecorder(watcher)
+		// the events are recorded as they pass, until the round ends
+		go func() {
+			for range r.recorder.Watch() {
+			}
+		}()
+		r.ownRecorder = true
 	}
-	if r.playerCount.max > MaxPlayerCount {
+	if r.playerCount.max <= 0 || r.playerCount.max > MaxPlayerCount {
 		r.playerCount.max = MaxPlayerCount
 	}
-	if r.playerCount.max < MinPlayerCount {
+	if r.playerCount.min < MinPlayerCount {
 		r.playerCount.min = MinPlayerCount
 	}
+	r.playerCount.current = len(r.players)
 	return r
 }
 
@@ -143,18 +268,118 @@ func WithNumber(number int) Option {
 // 	}
 // }
 
+// WithVariant deals the given game instead of hold'em.
+func WithVariant(v variant.Variant) Option {
+	return func(r *Round) {
+		r.variant = v
+	}
+}
+
+// WithLimit overrides the betting structure the variant is usually played with.
+func WithLimit(limit variant.Limit) Option {
+	return func(r *Round) {
+		r.limit = limit
+	}
+}
+
 func WithMinBet(minBet int) Option {
 	return func(r *Round) {
 		r.minBet = minBet
 	}
 }
 
+// WithBlinds sets the small and big blind amounts independently.
+func WithBlinds(small, big int) Option {
+	return func(r *Round) {
+		r.smallBlind = small
+		r.bigBlind = big
+	}
+}
+
+// WithAnte requires every player to post an ante before the hand starts.
+func WithAnte(ante int) Option {
+	return func(r *Round) {
+		r.ante = ante
+	}
+}
+
+// WithButtonAnte requires the button to post an ante for the whole table.
+func WithButtonAnte(ante int) Option {
+	return func(r *Round) {
+		r.buttonAnte = ante
+	}
+}
+
+// WithBigBlindAnte requires the big blind to post an ante for the whole table.
+func WithBigBlindAnte(ante int) Option {
+	return func(r *Round) {
+		r.bigBlindAnte = ante
+	}
+}
+
+// WithBlindSeats sets the seats of the blinds instead of deriving them from the button,
+// the button might be dead (an empty seat) then. A negative small blind seat means a
+// dead small blind, no one posts it.
+func WithBlindSeats(small, big int) Option {
+	return func(r *Round) {
+		r.smallBlindSeat = small
+		r.bigBlindSeat = big
+	}
+}
+
+// MissedBlinds indicates the blinds missed by a player while being away from the table.
+type MissedBlinds struct {
+	Small bool
+	Big   bool
+}
+
+// WithMissedBlinds requires the returning players to post the blinds they missed.
+func WithMissedBlinds(missed map[string]MissedBlinds) Option {
+	return func(r *Round) {
+		r.missed = missed
+	}
+}
+
+func WithStraddle(straddle Straddle) Option {
+	return func(r *Round) {
+		r.straddle = straddle
+	}
+}
+
+func WithClock(clock Clock) Option {
+	return func(r *Round) {
+		r.clock = clock
+	}
+}
+
+// WithRake makes the house take a share of the pots when they are settled.
+func WithRake(rake pots.RakePolicy) Option {
+	return func(r *Round) {
+		r.rake = rake
+	}
+}
+
+// WithLedger records the blinds, bets, rake and awards of the hand in the chip ledger.
+func WithLedger(l *ledger.Ledger) Option {
+	return func(r *Round) {
+		r.ledger = l
+	}
+}
+
 func WithDealer(dealer *dealer.Dealer) Option {
 	return func(r *Round) {
 		r.dealer = dealer
 	}
 }
 
+// WithDeck deals the hand from the given deck, in order, instead of a fresh shuffled
+// deck of the variant.
+func WithDeck(d *deck.Deck) Option {
+	return func(r *Round) {
+		r.deck = d
+	}
+}
+
 func WithBroadcaster(broadcaster watch.Broadcaster) Option {
 	return func(r *Round) {
 		r.broadcaster = broadcaster
@@ -195,10 +420,11 @@ func (e ErrPlayerAlreadyExists) Error() string {
 	return "player already exists"
 }
 
+// CountPlayer returns the number of players still in round, neither idle nor folded.
 func (r Round) CountPlayer() int {
 	count := 0
 	for _, p := range r.players {
-		if p != nil && p.Status() != player.StatusIdle {
+		if p != nil && p.Status() != player.StatusIdle && p.Status() != player.StatusFolded {
 			count++
 		}
 	}
@@ -288,37 +514,6 @@ func (r *Round) Watch() (watch.Interface, error) {
 // 	return nil
 // }
 
-// func (r *Round) prepare(ctx context.Context) error {
-// 	for _, p := range r.players {
-// 		if p == nil {
-// 			continue
-// 		}
-// 		watcher, err := r.broadcaster.Watch()
-// 		if err != nil {
-// 			return fmt.Errorf("failed to watch broadcaster: %w", err)
-// 		}
-// 		watcher = watch.Filter(watcher, func(in watch.Event) (out watch.Event, keep bool) {
-// 			// TODO(@yshngg): filter showdown event
-// 			// TODO(@yshngg): hiden burned card (dealer)
-// 			// hole cards are only visable to player own them
-// 			if in.Kind() == dealer.EventKind {
-// 				dealerEvent := in.(dealer.Event)
-// 				dealerEventObject := dealerEvent.Related().(dealer.EventObject)
-// 				if dealerEvent.Action() != dealer.EventDealHoleCards || dealerEventObject.To == dealer.ToPlayer(p) {
-// 					return in, true
-// 				}
-// 				// zero other player's hole cards
-// 				cards := make([]*card.Card, len(dealerEventObject.Cards))
-// 				dealer.NewEvent(dealerEvent.Action(), dealerEventObject.To, cards...)
-// 			}
-// 			return in, true
-// 		})
-// 		p.Apply(player.WithWatcher(watcher))
-// 	}
-
-// 	return nil
-// }
-
 type ErrInvalidPlayerCount struct {
 	count int
 }
@@ -336,34 +531,116 @@ func (e ErrInvalidButton) Error() string {
 }
 
 func (r *Round) betBlind(ctx context.Context) error {
+	r.blinds = make(map[string]int)
+	if r.variant.BringIn() {
+		// no blinds, the bring-in is posted once the up cards are dealt
+		for _, id := range r.position {
+			if _, ok := r.players[id]; !ok || r.ante <= 0 {
+				continue
+			}
+			if err := r.post(id, r.ante, player.EventPostAnte, false); err != nil {
+				return fmt.Errorf("post ante: %w", err)
+			}
+		}
+		return nil
+	}
 	small, big, err := r.positionBlind()
 	if err != nil {
 		return fmt.Errorf("blind positions, err: %v", err)
 	}
 
-	if err := r.players[small].Bet(ctx, r.minBet/2); err != nil {
-		return fmt.Errorf("post small blind: %w", err)
+	// antes are dead bets, they go to the pot but not toward the player's bet
+	if r.buttonAnte > 0 {
+		// a dead button, the next player in the hand posts it
+		seat, err := r.nextSeat(r.button - 1)
+		if err != nil {
+			return fmt.Errorf("button ante position, err: %w", err)
+		}
+		if err := r.post(r.position[seat], r.buttonAnte, player.EventPostButtonAnte, false); err != nil {
+			return fmt.Errorf("post button ante: %w", err)
+		}
+	} else if r.bigBlindAnte > 0 {
+		if err := r.post(r.position[big], r.bigBlindAnte, player.EventPostBigBlindAnte, false); err != nil {
+			return fmt.Errorf("post big blind ante: %w", err)
+		}
+	} else if r.ante > 0 {
+		for _, id := range r.position {
+			if _, ok := r.players[id]; !ok {
+				continue
+			}
+			if err := r.post(id, r.ante, player.EventPostAnte, false); err != nil {
+				return fmt.Errorf("post ante: %w", err)
+			}
+		}
+	}
+
+	// dead small blind, the player who should post it has left the table
+	if small >= 0 {
+		if err := r.post(r.position[small], r.smallBlind, player.EventPostSmallBlind, true); err != nil {
+			return fmt.Errorf("post small blind: %w", err)
+		}
 	}
-	if err := r.players[big].Bet(ctx, r.minBet); err != nil {
+	if err := r.post(r.position[big], r.bigBlind, player.EventPostBigBlind, true); err != nil {
 		return fmt.Errorf("post big blind: %w", err)
 	}
 
-	r.pots.AddChips(r.players[small].ID(), r.minBet/2)
-	r.pots.AddChips(r.players[big].ID(), r.minBet)
+	for _, id := range r.position {
+		missed, ok := r.missed[id]
+		if !ok {
+			continue
+		}
+		if missed.Small {
+			if err := r.post(id, r.smallBlind, player.EventPostDeadSmallBlind, false); err != nil {
+				return fmt.Errorf("post missed small blind: %w", err)
+			}
+		}
+		if missed.Big {
+			if err := r.post(id, r.bigBlind, player.EventPostMissedBigBlind, true); err != nil {
+				return fmt.Errorf("post missed big blind: %w", err)
+			}
+		}
+	}
 
-	smallBlindEvent := player.NewEvent(player.EventPostSmallBlind, player.EventObject{
-		ID:  r.players[small].ID(),
-		Bet: r.minBet / 2,
-	})
-	if err := r.broadcaster.Action(smallBlindEvent); err != nil {
-		return fmt.Errorf("broadcast event: %s, err: %w", player.EventPostSmallBlind, err)
+	if !r.straddling() {
+		return nil
+	}
+	straddle, err := r.positionStraddle()
+	if err != nil {
+		return fmt.Errorf("straddle position, err: %w", err)
+	}
+	if err := r.post(r.position[straddle], r.bigBlind*2, player.EventPostStraddle, true); err != nil {
+		return fmt.Errorf("post straddle: %w", err)
+	}
+	return nil
+}
+
+// post takes a compulsory bet from the player and broadcasts it as the given event.
+// Live bets count toward the player's pre-flop bet, dead bets only go to the pot.
+func (r *Round) post(id string, chips int, action player.EventAction, live bool) error {
+	p, ok := r.players[id]
+	if !ok {
+		return ErrPlayerNotFound{id: id}
+	}
+	chips = p.Post(chips)
+	r.pots.AddChips(p.ID(), chips)
+	kind := ledger.Blind
+	switch action {
+	case player.EventPostAnte, player.EventPostBigBlindAnte, player.EventPostButtonAnte:
+		kind = ledger.Ante
+	}
+	if err := r.record(kind, ledger.PlayerAccount(p.ID()), ledger.Pot, chips); err != nil {
+		return err
 	}
-	bigBlindEvent := player.NewEvent(player.EventPostSmallBlind, player.EventObject{
-		ID:  r.players[big].ID(),
-		Bet: r.minBet,
+	if live {
+		r.blinds[p.ID()] += chips
+	}
+
+	event := player.NewEvent(action, player.EventObject{
+		ID:  p.ID(),
+		Bet: chips,
 	})
-	if err := r.broadcaster.Action(bigBlindEvent); err != nil {
-		return fmt.Errorf("broadcast event: %s, err: %w", player.EventPostBigBlind, err)
+	if err := r.broadcaster.Action(event); err != nil {
+		return fmt.Errorf("broadcast event: %s, err: %w", action, err)
 	}
 	return nil
 }
@@ -380,93 +657,34 @@ func (r *Round) openBettingRound(ctx context.Context) (err error) {
 		return fmt.Errorf("invalid status: %s", r.status)
 	}
 
-	maxBet := 0
-	if r.status == StatusPreFlop {
-		maxBet = r.minBet
-	}
-	minRaise := r.minBet
-	bets := make(map[string]int) // chips that have bet in current betting round
-	small, big, err := r.positionBlind()
-	if err != nil {
-		return fmt.Errorf("position blind, err: %w", err)
+	stacks := make([]int, len(r.position))
+	bets := make([]int, len(r.position)) // chips that have bet in current betting round
+	folded := make([]bool, len(r.position))
+	for seat, id := range r.position {
+		p, ok := r.players[id]
+		if !ok || p.Status() == player.StatusFolded {
+			folded[seat] = true
+			continue
+		}
+		stacks[seat] = p.Chips()
+		if r.status == StatusPreFlop {
+			// live blinds and straddle count toward the pre-flop bets
+			bets[seat] = r.blinds[id]
+		}
 	}
-	bets[r.players[small].ID()] = r.minBet / 2
-	bets[r.players[big].ID()] = r.minBet
-	acted := make(map[string]bool) // map player id to whether they have acted in the current betting round
-	for _, p := range r.players {
-		acted[p.ID()] = false
+	size := r.minBet
+	if r.limit == variant.FixedLimit && r.variant.Streets()[r.street].BigBet {
+		size *= 2
 	}
+	betting := game.NewBettingRound(r.limit, size, stacks, bets, folded)
+
 	start, err := r.positionFirstToAct()
 	if err != nil {
 		return fmt.Errorf("position first to act, err: %w", err)
 	}
-
-	// TODO(@yshngg): player don't need to take any action after the player allin
-	next := func() bool {
-		for _, ok := range acted {
-			if !ok {
-				return true
-			}
-		}
-		for id, chips := range bets {
-			p, err := r.FindPlayer(id)
-			if err != nil {
-				// TODO(@yshngg): log error, but don't return or panic
-				continue
-			}
-			if p.Status() != player.StatusWaitingToAct {
-				continue
-			}
-			if chips != maxBet {
-				return true
-			}
-		}
-		return false
-	}
-
-	i := 0
-	for next() { // reopen the betting action
-		p := r.players[(start+i)%len(r.players)]
-		if p == nil {
-			// TODO(@yshngg): log error, but don't return or panic
-			// fmt.Errorf("player %s (id: %s) is not waiting to act (status: %s)", p.Name(), p.ID(), p.Status())
-			continue
-		}
-		if p.Status() == player.StatusFolded || p.Status() == player.StatusAllIn {
-			acted[p.ID()] = true
-			continue
-		}
-		availableActions := []player.Action{
-			{
-				Type: player.ActionFold,
-			},
-			{
-				Type: player.ActionAllIn,
-			},
-		}
-		if maxBet == 0 {
-			availableActions = append(availableActions, []player.Action{
-				{
-					Type:  player.ActionBet,
-					Chips: r.minBet,
-				},
-				{
-					Type: player.ActionCheck,
-				},
-			}...)
-		} else {
-			availableActions = []player.Action{
-				{
-					Type:  player.ActionCall,
-					Chips: (maxBet - bets[p.ID()]),
-				},
-				{
-					Type:  player.ActionRaise,
-					Chips: (maxBet - bets[p.ID()]) + minRaise,
-				},
-			}
-		}
-		action, err := p.WaitForAction(ctx, availableActions)
+	for seat := betting.ToAct(start); seat >= 0; seat = betting.ToAct(seat + 1) {
+		p := r.players[r.position[seat]]
+		action, err := r.waitForAction(ctx, p, betting.Actions(seat, r.pots.Sum()))
 		if err != nil {
 			return fmt.Errorf("wait for action, err: %w", err)
 		}
@@ -474,28 +692,52 @@ func (r *Round) openBettingRound(ctx context.Context) (err error) {
 		switch action.Type {
 		case player.ActionAllIn, player.ActionRaise, player.ActionBet, player.ActionCall:
 			r.pots.AddChips(p.ID(), action.Chips)
-			bets[p.ID()] += action.Chips
-		}
-
-		switch action.Type {
-		case player.ActionAllIn:
-			if bets[p.ID()] > maxBet {
-				maxBet = bets[p.ID()]
+			if err := r.record(ledger.Bet, ledger.PlayerAccount(p.ID()), ledger.Pot, action.Chips); err != nil {
+				return err
 			}
-		case player.ActionRaise:
-			minRaise = bets[p.ID()] - maxBet
-			maxBet = bets[p.ID()]
-		case player.ActionBet:
-			maxBet = action.Chips
-			minRaise = maxBet
 		}
-		acted[p.ID()] = true
-		i++
+		betting.Apply(seat, *action)
 	}
-
 	return nil
 }
 
+// waitForAction waits for the player to act on the clock: the base time of the street
+// first, then the player's time bank once the base time runs out.
+func (r *Round) waitForAction(ctx context.Context, p *player.Player, available []player.Action) (*player.Action, error) {
+	base, bank := r.clock.base(r.status), p.TimeBank()
+	clockStartEvent := player.NewEvent(player.EventClockStart, player.EventObject{ID: p.ID(), Timeout: base})
+	if err := r.broadcaster.Action(clockStartEvent); err != nil {
+		return nil, fmt.Errorf("broadcast event: %s, err: %w", player.EventClockStart, err)
+	}
+	if bank > 0 {
+		timer := time.AfterFunc(base, func() {
+			timeBankEvent := player.NewEvent(player.EventTimeBank, player.EventObject{ID: p.ID(), Timeout: bank})
+			// TODO(@yshngg): log error, but don't return or panic
+			_ = r.broadcaster.Action(timeBankEvent)
+		})
+		defer timer.Stop()
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeoutCause(ctx, base+bank, fmt.Errorf("action clock expired"))
+	defer cancel()
+	action, err := p.WaitForAction(ctx, available)
+	if elapsed := time.Since(start); elapsed > base {
+		p.SpendTimeBank(elapsed - base)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Err() != nil {
+		clockExpiredEvent := player.NewEvent(player.EventClockExpired, player.EventObject{ID: p.ID()})
+		if err := r.broadcaster.Action(clockExpiredEvent); err != nil {
+			return nil, fmt.Errorf("broadcast event: %s, err: %w", player.EventClockExpired, err)
+		}
+	}
+	return action, nil
+}
+
 type ErrBroadcast struct {
 	Event watch.Event
 }
@@ -505,8 +747,9 @@ func (e ErrBroadcast) Error() string {
 }
 
 func (r *Round) Start(ctx context.Context) error {
-	if r.players[r.button] == nil {
-		return fmt.Errorf("button position does not have player")
+	// the button might be dead, but it must be a seat in round
+	if r.button >= len(r.position) {
+		return ErrInvalidButton{button: r.button}
 	}
 	playerCount := r.CountPlayer()
 	if playerCount < r.playerCount.min || playerCount > r.playerCount.max {
@@ -517,22 +760,22 @@ func (r *Round) Start(ctx context.Context) error {
 	// ready to start the round
 	r.status = StatusStarted
 
-	// prepare players
-	err := r.prepare(ctx)
-	if err != nil {
-		return fmt.Errorf("start round, err: %w", err)
-	}
-
-	roundStartEvent := NewEvent(EventStart, r.players, nil)
+	// the table gives the players their watchers, the hole cards of the others hidden
+	roundStartEvent := NewEvent(EventStart, r.Players())
 	if err := r.broadcaster.Action(roundStartEvent); err != nil {
 		return fmt.Errorf("broadcast event: %v, err: %w", roundStartEvent, err)
 	}
 
-	// dealer shuffle deck
-	r.dealer.Shuffle()
-	dealerShuffleEvent := dealer.NewEvent(dealer.EventShuffle, dealer.ToAll())
-	if err := r.broadcaster.Action(dealerShuffleEvent); err != nil {
-		return fmt.Errorf("broadcast event: %v, err: %w", dealerShuffleEvent, err)
+	// dealer shuffle a fresh deck of the variant, unless the deck is given
+	if r.deck != nil {
+		r.dealer.SetDeck(r.deck)
+	} else {
+		r.dealer.SetDeck(r.variant.Deck())
+		r.dealer.Shuffle()
+		dealerShuffleEvent := dealer.NewEvent(dealer.EventShuffle, dealer.ToAll())
+		if err := r.broadcaster.Action(dealerShuffleEvent); err != nil {
+			return fmt.Errorf("broadcast event: %v, err: %w", dealerShuffleEvent, err)
+		}
 	}
 
 	// compulsory bets
@@ -540,127 +783,100 @@ func (r *Round) Start(ctx context.Context) error {
 		return fmt.Errorf("bet blind, err: %w", err)
 	}
 
-	// pre-flop
-	r.status = StatusPreFlop
-	holeCards := r.dealer.DealHoleCards(playerCount)
-	for i := range len(r.players) {
-		p := r.players[(r.button+i+1)%len(r.players)]
-		if p == nil {
-			continue
+	for i, street := range r.variant.Streets() {
+		r.street = i
+		r.status = streetStatus(i)
+		if i == 1 {
+			r.flopPlayers = len(r.inHand())
 		}
-		p.SetHoleCards(holeCards[i])
-		dealHoleCardsEvent := dealer.NewEvent(dealer.EventDealHoleCards, dealer.ToPlayer(p), holeCards[i][:]...)
-		if err := r.broadcaster.Action(dealHoleCardsEvent); err != nil {
-			return fmt.Errorf("broadcast event: %v, err: %w", dealHoleCardsEvent, err)
+		if err := r.playStreet(ctx, street); err != nil {
+			return err
+		}
+		if len(r.inHand()) == 1 {
+			// the others have folded, no more cards are dealt
+			break
+		}
+		if r.allIn() {
+			return r.runout(ctx)
 		}
-	}
-	// pre-flop betting round
-	err = r.openBettingRound(ctx)
-	if err != nil {
-		return fmt.Errorf("open betting round: err: %w", err)
-	}
-
-	// flop
-	r.status = StatusFlop
-	burnCard := r.dealer.BurnCard()
-	burnCardEvent := dealer.NewEvent(dealer.EventDealFlopCards, dealer.ToCommunity(), burnCard)
-	if err := r.broadcaster.Action(burnCardEvent); err != nil {
-		return fmt.Errorf("broadcast event: %v, err: %w", burnCardEvent, err)
-	}
-	flopCards := r.dealer.DealFlopCards()
-	for _, card := range flopCards {
-		r.communityCards = append(r.communityCards, card)
-	}
-	dealFlopCardsEvent := dealer.NewEvent(dealer.EventDealFlopCards, dealer.ToCommunity(), flopCards[:]...)
-	if err := r.broadcaster.Action(dealFlopCardsEvent); err != nil {
-		return fmt.Errorf("broadcast event: %v, err: %w", dealFlopCardsEvent, err)
 	}
 
-	// flop betting round
-	err = r.openBettingRound(ctx)
-	if err != nil {
-		return fmt.Errorf("open betting round: err: %w", err)
-	}
+	return r.showdown([][]*card.Card{r.communityCards})
+}
 
-	// turn
-	r.status = StatusTurn
-	burnCard = r.dealer.BurnCard()
-	burnCardEvent = dealer.NewEvent(dealer.EventDealFlopCards, dealer.ToCommunity(), burnCard)
-	if err := r.broadcaster.Action(burnCardEvent); err != nil {
-		return fmt.Errorf("broadcast event: %v, err: %w", burnCardEvent, err)
+// settle settles the pots, taking the rake from them.
+func (r *Round) settle() ([]pots.Pot, error) {
+	settled := r.rake.Apply(r.pots.Settle(), len(r.players), r.street >= 1)
+	r.raked = make([]int, len(settled))
+	for i, pot := range settled {
+		r.raked[i] = pot.Rake()
+		if err := r.record(ledger.Rake, ledger.Pot, ledger.House, pot.Rake()); err != nil {
+			return nil, err
+		}
 	}
-	turnCard := r.dealer.DealTurnCard()
-	r.communityCards = append(r.communityCards, turnCard)
-	dealTurnCardEvent := dealer.NewEvent(dealer.EventDealTurnCard, dealer.ToCommunity(), turnCard)
-	if err := r.broadcaster.Action(dealTurnCardEvent); err != nil {
-		return fmt.Errorf("broadcast event: %v, err: %w", dealTurnCardEvent, err)
+	rakeEvent := NewPotsEvent(EventRake, settled)
+	if err := r.broadcaster.Action(rakeEvent); err != nil {
+		return nil, fmt.Errorf("broadcast event: %v, err: %w", rakeEvent, err)
 	}
+	return settled, nil
+}
 
-	// turn betting round
-	err = r.openBettingRound(ctx)
-	if err != nil {
-		return fmt.Errorf("open betting round: err: %w", err)
+// award pays the chips won from a pot to the player.
+func (r *Round) award(id string, chips int) error {
+	p, ok := r.players[id]
+	if !ok {
+		return ErrPlayerNotFound{id: id}
 	}
+	p.AddChips(chips)
+	return r.record(ledger.Award, ledger.Pot, ledger.PlayerAccount(id), chips)
+}
 
-	// river
-	r.status = StatusRiver
-	burnCard = r.dealer.BurnCard()
-	burnCardEvent = dealer.NewEvent(dealer.EventDealFlopCards, dealer.ToCommunity(), burnCard)
-	if err := r.broadcaster.Action(burnCardEvent); err != nil {
-		return fmt.Errorf("broadcast event: %v, err: %w", burnCardEvent, err)
+// record records the chips moved in the chip ledger, if any.
+func (r *Round) record(kind ledger.Kind, from, to ledger.Account, chips int) error {
+	if r.ledger == nil || chips <= 0 {
+		return nil
 	}
-	riverCard := r.dealer.DealTurnCard()
-	r.communityCards = append(r.communityCards, riverCard)
-	dealRiverCardEvent := dealer.NewEvent(dealer.EventDealRiverCard, dealer.ToCommunity(), riverCard)
-	if err := r.broadcaster.Action(dealRiverCardEvent); err != nil {
-		return fmt.Errorf("broadcast event: %v, err: %w", dealRiverCardEvent, err)
-	}
-
-	// turn betting round
-	err = r.openBettingRound(ctx)
-	if err != nil {
-		return fmt.Errorf("open betting round: err: %w", err)
+	if _, err := r.ledger.Transfer(kind, from, to, chips); err != nil {
+		return fmt.Errorf("record %s, err: %w", kind, err)
 	}
+	return nil
+}
 
-	// showdown
-	r.status = StatusShowdown
-	// TODO(@yshngg): showdown???
-	// cards, err := r.Showdown(ctx)
+// Pot returns the chips bet in the hand.
+func (r *Round) Pot() int {
+	return r.pots.Sum()
+}
 
-	for _, pot := range r.pots.Settle() {
-		players := make([]*player.Player, len(r.players))
-		for id := range pot.Contributors() {
-			p := new(player.Player)
-			if p, err = r.FindPlayer(id); err != nil {
-				return fmt.Errorf("find player (id: %s), err: %w", id, err)
-			}
-			if p.Status() != player.StatusTakingAction && p.Status() != player.StatusAllIn {
-				continue
-			}
-			players = append(players, p)
-			// TODO(@yshngg): compare player's best five cards and win the pot
-		}
-	}
+// PlayerCount returns the number of players dealt in.
+func (r *Round) PlayerCount() int {
+	return len(r.players)
+}
 
-	roundShowdownEvent := NewEvent(EventShowdown, players, r.communityCards...)
-	if err := r.broadcaster.Action(roundShowdownEvent); err != nil {
-		return fmt.Errorf("broadcast event: %v, err: %w", roundShowdownEvent, err)
-	}
+// FlopPlayers returns the number of players who have seen the flop, zero if the
+// hand has ended before the flop.
+func (r *Round) FlopPlayers() int {
+	return r.flopPlayers
+}
 
-	return nil
+// Rake returns the rake taken from every pot once the pots are settled, from the main pot.
+func (r *Round) Rake() []int {
+	return r.raked
 }
 
 func (r *Round) End() error {
 	r.status = StatusEnd
+	// the players keep watching the table between the hands
 	for _, p := range r.players {
-		p.StopWatch()
 		p.Reset()
 	}
+	if r.ownRecorder {
+		r.recorder.Stop()
+	}
 	return nil
 }
 
-func (r *Round) Showdown(ctx context.Context) (map[string][2]*card.Card, error) {
-	holeCards := make(map[string][2]*card.Card, 0)
+func (r *Round) Showdown(ctx context.Context) (map[string][]*card.Card, error) {
+	holeCards := make(map[string][]*card.Card, 0)
 	for _, p := range r.players {
 		if p.Status() == player.StatusFolded {
 			continue
@@ -677,9 +893,9 @@ func (r *Round) Showdown(ctx context.Context) (map[string][2]*card.Card, error)
 	var id string
 	for id = range holeCards {
 	}
-	p, err := r.FindPlayer(id)
-	if err != nil {
-		return nil, fmt.Errorf("find player, err: %w", err)
+	p, ok := r.players[id]
+	if !ok {
+		return nil, ErrPlayerNotFound{id: id}
 	}
 	action, err := p.WaitForAction(ctx, []player.Action{
 		player.Action{Type: player.ActionHideHoleCards},
@@ -690,19 +906,16 @@ func (r *Round) Showdown(ctx context.Context) (map[string][2]*card.Card, error)
 	}
 	if action.Type != player.ActionShowHoleCards {
 		// zero hole cards
-		cards := holeCards[id]
-		for i := range len(cards) {
-			cards[i] = nil
-		}
-		holeCards[id] = cards
+		holeCards[id] = make([]*card.Card, len(holeCards[id]))
 	}
 	return holeCards, nil
 }
 
+// Players returns the players in round, by seats.
 func (r *Round) Players() []*player.Player {
 	players := make([]*player.Player, 0, len(r.players))
-	for _, p := range r.players {
-		if p != nil || p.Status() != player.StatusIdle {
+	for _, id := range r.position {
+		if p, ok := r.players[id]; ok {
 			players = append(players, p)
 		}
 	}
@@ -726,40 +939,56 @@ func (e ErrStatusNotSupported) Error() string {
 }
 
 func (r Round) positionBlind() (int, int, error) {
+	if r.bigBlindSeat >= 0 {
+		// given by the table, the small blind might be dead
+		return r.smallBlindSeat, r.bigBlindSeat, nil
+	}
+
 	playerCount := r.playerCount.current
-	length := len(r.players)
-	if r.button < 0 || length <= r.button || r.players[r.button] == nil {
+	length := len(r.position)
+	if r.button < 0 || length <= r.button || r.position[r.button] == "" {
 		return -1, -1, ErrInvalidButton{button: r.button}
 	}
 	if playerCount < 2 {
 		return -1, -1, ErrInvalidPlayerCount{count: playerCount}
 	}
 	if playerCount == 2 {
+		// heads-up, the button posts the small blind
 		small := r.button
-		big := (small + 1) % length
-		for r.players[big] == nil {
-			big = (big + 1) % length
+		big, err := r.nextSeat(small)
+		if err != nil {
+			return -1, -1, err
 		}
 		return small, big, nil
 	}
 
-	small := (r.button + 1) % length
-	big := (small + 1) % length
-	for range length {
-		if r.players[small] == nil {
-			small = (small + 1) % length
-			big = (small + 1) % length
-			continue
-		}
-		if r.players[big] != nil {
-			break
-		}
-		big = (big + 1) % length
+	small, err := r.nextSeat(r.button)
+	if err != nil {
+		return -1, -1, err
+	}
+	big, err := r.nextSeat(small)
+	if err != nil {
+		return -1, -1, err
 	}
 	return small, big, nil
 }
 
+// nextSeat returns the first seat after the given one which has a player in round.
+func (r Round) nextSeat(seat int) (int, error) {
+	length := len(r.position)
+	for i := range length {
+		next := (seat + i + 1) % length
+		if _, ok := r.players[r.position[next]]; ok {
+			return next, nil
+		}
+	}
+	return -1, ErrInvalidPlayerCount{count: len(r.players)}
+}
+
 func (r *Round) positionFirstToAct() (int, error) {
+	if r.firstToAct >= 0 {
+		return r.firstToAct, nil
+	}
 	playerCount := r.playerCount.current
 	if playerCount < 2 {
 		return -1, ErrInvalidPlayerCount{count: playerCount}
@@ -775,20 +1004,73 @@ func (r *Round) positionFirstToAct() (int, error) {
 		if playerCount == 2 {
 			return small, nil
 		}
-		length := len(r.players)
-		for i := range length - 2 {
-			p := r.players[(big+i+1)%length]
-			if p != nil {
-				return i, nil
+		if r.straddling() {
+			straddle, err := r.positionStraddle()
+			if err != nil {
+				return -1, fmt.Errorf("position straddle, err: %w", err)
 			}
+			if r.straddle == StraddleButton {
+				// the straddler on the button acts last, the small blind opens the action
+				return small, nil
+			}
+			// the action starts left to the straddler
+			big = straddle
+		}
+		seat, err := r.nextSeat(big)
+		if err != nil {
+			return -1, ErrFirstToActPlayerNotFound{r.button}
 		}
-		return -1, ErrFirstToActPlayerNotFound{r.button}
+		return seat, nil
 	case StatusFlop, StatusTurn, StatusRiver:
 		if playerCount == 2 {
 			return big, nil
 		}
-		return small, nil
+		// the first player left to the button, even if the button or small blind is dead
+		seat, err := r.nextSeat(r.button)
+		if err != nil {
+			return -1, ErrFirstToActPlayerNotFound{r.button}
+		}
+		return seat, nil
 	default:
 		return -1, ErrStatusNotSupported{status: r.status}
 	}
 }
+
+type ErrStraddleNotSupported struct {
+	straddle Straddle
+}
+
+func (e ErrStraddleNotSupported) Error() string {
+	return fmt.Sprintf("straddle not supported: %s", e.straddle)
+}
+
+// straddling reports whether a straddle is posted in the round.
+// Straddles are not allowed heads-up, nor on a dead button.
+func (r Round) straddling() bool {
+	if r.straddle == StraddleNone || r.playerCount.current <= 2 {
+		return false
+	}
+	if r.straddle == StraddleButton {
+		_, ok := r.players[r.position[r.button]]
+		return ok
+	}
+	return true
+}
+
+func (r Round) positionStraddle() (int, error) {
+	if !r.straddling() {
+		return -1, ErrStraddleNotSupported{straddle: r.straddle}
+	}
+	_, big, err := r.positionBlind()
+	if err != nil {
+		return -1, fmt.Errorf("position blind, err: %w", err)
+	}
+
+	switch r.straddle {
+	case StraddleButton:
+		return r.button, nil
+	case StraddleUTG:
+		return r.nextSeat(big)
+	}
+	return -1, ErrStraddleNotSupported{straddle: r.straddle}
+}
@@ -2,9 +2,16 @@ package round
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/deck"
+	"github.com/yshngg/holdem/pkg/ledger"
 	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/watch"
 )
 
 func TestPositionBlind(t *testing.T) {
@@ -131,7 +138,7 @@ func TestPositionBlind(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			r := New(tc.players, WithButton(tc.button))
+			r := New(tc.players, tc.button)
 			small, big, err := r.positionBlind()
 			if err != tc.want.err {
 				t.Errorf("err: %v, want: %v", err, tc.want.err)
@@ -160,33 +167,33 @@ func TestCountPlayer(t *testing.T) {
 		{
 			name: "OnePlayer",
 			players: []*player.Player{
-				player.New(),
+				ready(),
 			},
 			want: 1,
 		},
 		{
 			name: "TwoPlayers",
 			players: []*player.Player{
-				player.New(),
-				player.New(),
+				ready(),
+				ready(),
 			},
 			want: 2,
 		},
 		{
 			name: "ThreePlayers",
 			players: []*player.Player{
-				player.New(),
-				player.New(),
-				player.New(),
+				ready(),
+				ready(),
+				ready(),
 			},
 			want: 3,
 		},
 		{
 			name: "ThreePlayers",
 			players: []*player.Player{
-				player.New(),
-				player.New(),
-				player.New(),
+				ready(),
+				ready(),
+				ready(),
 				nil,
 				nil,
 			},
@@ -195,9 +202,9 @@ func TestCountPlayer(t *testing.T) {
 		{
 			name: "ThreePlayers",
 			players: []*player.Player{
-				player.New(),
-				player.New(),
-				player.New(),
+				ready(),
+				ready(),
+				ready(),
 				nil,
 				player.New(player.WithStatus(player.StatusFolded)),
 			},
@@ -206,9 +213,9 @@ func TestCountPlayer(t *testing.T) {
 		{
 			name: "ThreePlayers",
 			players: []*player.Player{
-				player.New(),
-				player.New(),
-				player.New(),
+				ready(),
+				ready(),
+				ready(),
 				player.New(player.WithStatus(player.StatusFolded)),
 				player.New(player.WithStatus(player.StatusFolded)),
 			},
@@ -219,8 +226,7 @@ func TestCountPlayer(t *testing.T) {
 			players: []*player.Player{
 				player.New(player.WithStatus(player.StatusIdle)),
 				player.New(player.WithStatus(player.StatusReady)),
-				player.New(player.WithStatus(player.StatusWaitingToAct)),
-				player.New(player.WithStatus(player.StatusTakingAction)),
+				player.New(player.WithStatus(player.StatusWaiting)),
 				player.New(player.WithStatus(player.StatusFolded)),
 				player.New(player.WithStatus(player.StatusAllIn)),
 			},
@@ -230,7 +236,7 @@ func TestCountPlayer(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			r := New(tc.players)
+			r := New(tc.players, defaultButton)
 			count := r.CountPlayer()
 			if count != tc.want {
 				t.Errorf("effective player count: %v, want: %v", count, tc.want)
@@ -243,11 +249,405 @@ func TestRound(t *testing.T) {
 	playerCount := 5
 	playerChips := 100
 	players := make([]*player.Player, 0, playerCount)
-	for i := range 5 {
+	for i := range playerCount {
 		p := player.New(player.WithName(fmt.Sprintf("player-%d", i)), player.WithChips(playerChips))
+		if err := p.Ready(); err != nil {
+			t.Fatalf("player ready, err: %v", err)
+		}
 		players = append(players, p)
 	}
-	r := New(players)
-	r.Start(t.Context())
+	// nobody acts in time, everyone folds to the big blind
+	r := New(players, 0, WithClock(Clock{Base: time.Millisecond}))
+	if err := r.Start(t.Context()); err != nil {
+		t.Fatalf("start round, err: %v", err)
+	}
 	r.End()
+	for i, p := range players {
+		want := playerChips
+		switch i {
+		case 1:
+			want = playerChips - 1
+		case 2:
+			want = playerChips + 1
+		}
+		if p.Chips() != want {
+			t.Errorf("player %d chips: %d, want: %d", i, p.Chips(), want)
+		}
+	}
+}
+
+// ready returns a player ready to be dealt in.
+func ready() *player.Player {
+	return player.New(player.WithStatus(player.StatusReady))
+}
+
+// bot returns a ready player who takes the action the policy picks as soon as the
+// clock of the round runs out.
+func bot(id string, chips int, policy player.TimeoutPolicy) *player.Player {
+	p := player.New(player.WithID(id), player.WithChips(chips), player.WithTimeoutPolicy(policy))
+	if err := p.Ready(); err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// prefer picks the first available action of the given types, in order, or checks
+// or folds.
+func prefer(types ...player.ActionType) player.TimeoutPolicy {
+	return func(available []player.Action) (player.Action, error) {
+		for _, actionType := range types {
+			for _, action := range available {
+				if action.Type == actionType {
+					return action, nil
+				}
+			}
+		}
+		return player.CheckOrFold(available)
+	}
+}
+
+// play deals a hand to the players by seats, a nil player is an empty seat, and
+// returns the ledger of the hand.
+func play(t *testing.T, players []*player.Player, button int, opts ...Option) *ledger.Ledger {
+	t.Helper()
+	return deal(t, players, button, opts...).ledger
+}
+
+// deal deals a hand to the players by seats as play does, and returns the round
+// ended, all its events recorded.
+func deal(t *testing.T, players []*player.Player, button int, opts ...Option) *Round {
+	t.Helper()
+	l := ledger.New()
+	for _, p := range players {
+		if p == nil {
+			continue
+		}
+		if _, err := l.BuyIn(t.Context(), nil, p.ID(), ledger.BuyIn, p.Chips(), p.Chips()); err != nil {
+			t.Fatalf("buy in, err: %v", err)
+		}
+	}
+	// the events are recorded until the broadcaster has distributed them all
+	b := watch.NewBroadcaster(len(players)*2, len(players)*2)
+	w, err := b.Watch()
+	if err != nil {
+		t.Fatalf("watch, err: %v", err)
+	}
+	recorder := watch.NewRecorder(w)
+	recorded := make(chan struct{})
+	go func() {
+		defer close(recorded)
+		for range recorder.Watch() {
+		}
+	}()
+
+	opts = append([]Option{
+		WithBlinds(1, 2), WithLedger(l), WithClock(Clock{Base: time.Millisecond}),
+		WithBroadcaster(b), WithRecorder(recorder),
+	}, opts...)
+	r := New(players, button, opts...)
+	if err := r.Start(t.Context()); err != nil {
+		t.Fatalf("start round, err: %v", err)
+	}
+	r.End()
+	b.Shutdown()
+	<-recorded
+	if !l.Balanced() {
+		t.Errorf("ledger is not balanced: %v", l.Transactions())
+	}
+	return r
+}
+
+// stacked returns a deck of the cards, in order, then the rest of a new deck.
+func stacked(cards ...card.Card) *deck.Deck {
+	rest := slices.DeleteFunc(deck.New().List(), func(c card.Card) bool {
+		return slices.Contains(cards, c)
+	})
+	return deck.Of(append(cards, rest...)...)
+}
+
+// acted returns the players in the order they were put on the clock.
+func acted(r *Round) []string {
+	ids := make([]string, 0)
+	for _, event := range r.recorder.Events() {
+		if event.Kind() == player.EventKind && event.Action() == string(player.EventClockStart) {
+			ids = append(ids, event.Related().(player.EventObject).ID)
+		}
+	}
+	return ids
+}
+
+type posting struct {
+	kind  ledger.Kind
+	id    string
+	chips int
+}
+
+func TestBetBlind(t *testing.T) {
+	seats := func(ids ...string) []*player.Player {
+		players := make([]*player.Player, 0, len(ids))
+		for _, id := range ids {
+			if id == "" {
+				players = append(players, nil)
+				continue
+			}
+			players = append(players, bot(id, 100, player.CheckOrFold))
+		}
+		return players
+	}
+	testCases := []struct {
+		name    string
+		players []*player.Player
+		button  int
+		opts    []Option
+		want    []posting
+	}{
+		{
+			name:    "Blinds",
+			players: seats("p0", "p1", "p2"),
+			want:    []posting{{ledger.Blind, "p1", 1}, {ledger.Blind, "p2", 2}},
+		},
+		{
+			name:    "HeadsUp",
+			players: seats("p0", "p1"),
+			want:    []posting{{ledger.Blind, "p0", 1}, {ledger.Blind, "p1", 2}},
+		},
+		{
+			name:    "Ante",
+			players: seats("p0", "p1", "p2"),
+			opts:    []Option{WithAnte(1)},
+			want: []posting{
+				{ledger.Ante, "p0", 1}, {ledger.Ante, "p1", 1}, {ledger.Ante, "p2", 1},
+				{ledger.Blind, "p1", 1}, {ledger.Blind, "p2", 2},
+			},
+		},
+		{
+			name:    "BigBlindAnte",
+			players: seats("p0", "p1", "p2"),
+			opts:    []Option{WithBigBlindAnte(3)},
+			want:    []posting{{ledger.Ante, "p2", 3}, {ledger.Blind, "p1", 1}, {ledger.Blind, "p2", 2}},
+		},
+		{
+			name:    "ButtonAnte",
+			players: seats("p0", "p1", "p2"),
+			opts:    []Option{WithButtonAnte(3)},
+			want:    []posting{{ledger.Ante, "p0", 3}, {ledger.Blind, "p1", 1}, {ledger.Blind, "p2", 2}},
+		},
+		{
+			// the next player in the hand posts the ante of a dead button
+			name:    "DeadButtonAnte",
+			players: seats("", "p1", "p2", "p3"),
+			opts:    []Option{WithBlindSeats(1, 2), WithButtonAnte(3)},
+			want:    []posting{{ledger.Ante, "p1", 3}, {ledger.Blind, "p1", 1}, {ledger.Blind, "p2", 2}},
+		},
+		{
+			name:    "DeadSmallBlind",
+			players: seats("p0", "", "p2", "p3"),
+			opts:    []Option{WithBlindSeats(-1, 2)},
+			want:    []posting{{ledger.Blind, "p2", 2}},
+		},
+		{
+			name:    "DeadButton",
+			players: seats("", "p1", "p2", "p3"),
+			opts:    []Option{WithBlindSeats(1, 2)},
+			want:    []posting{{ledger.Blind, "p1", 1}, {ledger.Blind, "p2", 2}},
+		},
+		{
+			name:    "MissedBlinds",
+			players: seats("p0", "p1", "p2", "p3"),
+			opts:    []Option{WithMissedBlinds(map[string]MissedBlinds{"p3": {Small: true, Big: true}})},
+			want: []posting{
+				{ledger.Blind, "p1", 1}, {ledger.Blind, "p2", 2},
+				{ledger.Blind, "p3", 1}, {ledger.Blind, "p3", 2},
+			},
+		},
+		{
+			name:    "StraddleUTG",
+			players: seats("p0", "p1", "p2", "p3"),
+			opts:    []Option{WithStraddle(StraddleUTG)},
+			want:    []posting{{ledger.Blind, "p1", 1}, {ledger.Blind, "p2", 2}, {ledger.Blind, "p3", 4}},
+		},
+		{
+			name:    "StraddleButton",
+			players: seats("p0", "p1", "p2", "p3"),
+			opts:    []Option{WithStraddle(StraddleButton)},
+			want:    []posting{{ledger.Blind, "p1", 1}, {ledger.Blind, "p2", 2}, {ledger.Blind, "p0", 4}},
+		},
+		{
+			// no one straddles on a dead button
+			name:    "StraddleDeadButton",
+			players: seats("", "p1", "p2", "p3"),
+			opts:    []Option{WithBlindSeats(1, 2), WithStraddle(StraddleButton)},
+			want:    []posting{{ledger.Blind, "p1", 1}, {ledger.Blind, "p2", 2}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := play(t, tc.players, tc.button, tc.opts...)
+			got := make([]posting, 0)
+			for _, tx := range l.Transactions() {
+				if tx.Kind == ledger.Ante || tx.Kind == ledger.Blind {
+					got = append(got, posting{tx.Kind, string(tx.From)[len("player:"):], tx.Chips})
+				}
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("postings: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStraddle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		straddle Straddle
+		order    []string
+		raise    int
+	}{
+		// the straddler acts last pre-flop, the least raise is to twice the
+		// straddle, the small blind puts in one chip less
+		{"UTG", StraddleUTG, []string{"p0", "p1", "p2", "p3"}, 8},
+		{"Button", StraddleButton, []string{"p1", "p2", "p3", "p0"}, 7},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var order []string
+			var raise player.Action
+			players := make([]*player.Player, 0, 4)
+			for i := range 4 {
+				id := fmt.Sprintf("p%d", i)
+				players = append(players, bot(id, 100, func(available []player.Action) (player.Action, error) {
+					if len(order) == 0 {
+						raise = available[slices.IndexFunc(available, func(a player.Action) bool {
+							return a.Type == player.ActionRaise
+						})]
+					}
+					order = append(order, id)
+					return prefer(player.ActionCheck, player.ActionCall)(available)
+				}))
+			}
+			play(t, players, 0, WithStraddle(tc.straddle))
+			if !slices.Equal(order[:4], tc.order) {
+				t.Errorf("pre-flop order: %v, want: %v", order[:4], tc.order)
+			}
+			if raise.Chips != tc.raise {
+				t.Errorf("least raise: %d, want: %d", raise.Chips, tc.raise)
+			}
+		})
+	}
+}
+
+func TestDeadButton(t *testing.T) {
+	var order []string
+	players := []*player.Player{nil}
+	for i := 1; i < 4; i++ {
+		id := fmt.Sprintf("p%d", i)
+		players = append(players, bot(id, 100, func(available []player.Action) (player.Action, error) {
+			order = append(order, id)
+			return prefer(player.ActionCheck, player.ActionCall)(available)
+		}))
+	}
+	play(t, players, 0, WithBlindSeats(1, 2))
+
+	// the first player left of the big blind opens, then left of the dead button
+	want := []string{"p3", "p1", "p2", "p1", "p2", "p3", "p1", "p2", "p3", "p1", "p2", "p3"}
+	if !slices.Equal(order, want) {
+		t.Errorf("order: %v, want: %v", order, want)
+	}
+	chips := 0
+	for _, p := range players[1:] {
+		chips += p.Chips()
+	}
+	if chips != 300 {
+		t.Errorf("chips: %d, want: %d", chips, 300)
+	}
+}
+
+// timingOut lets CheckOrFold act on every decision offering one of the given actions,
+// and records the actions taken. The policy acts on the other decisions.
+func timingOut(taken *[]player.ActionType, policy player.TimeoutPolicy, offered ...player.ActionType) player.TimeoutPolicy {
+	return func(available []player.Action) (player.Action, error) {
+		if !slices.ContainsFunc(available, func(action player.Action) bool { return slices.Contains(offered, action.Type) }) {
+			return policy(available)
+		}
+		action, err := player.CheckOrFold(available)
+		*taken = append(*taken, action.Type)
+		return action, err
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	check, fold := player.ActionCheck, player.ActionFold
+	testCases := []struct {
+		name string
+		// bet is the decision of p0 to bet on, the first one raises the big blind
+		bet  int
+		want []player.ActionType
+	}{
+		{"PreFlop", 1, []player.ActionType{fold}},
+		{"Flop", 2, []player.ActionType{check, check, fold}},
+		{"Turn", 3, []player.ActionType{check, check, check, fold}},
+		{"River", 4, []player.ActionType{check, check, check, check, fold}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decisions := 0
+			betting := func(available []player.Action) (player.Action, error) {
+				if decisions++; decisions == tc.bet {
+					return prefer(player.ActionRaise, player.ActionBet)(available)
+				}
+				return prefer(player.ActionCheck, player.ActionCall)(available)
+			}
+			// p1 checks the big blind and every street, until folding to the bet
+			var taken []player.ActionType
+			players := []*player.Player{
+				bot("p0", 100, betting),
+				bot("p1", 100, timingOut(&taken, player.CheckOrFold, player.ActionCheck, player.ActionFold)),
+			}
+			l := play(t, players, 0)
+
+			if !slices.Equal(taken, tc.want) {
+				t.Errorf("taken on timeout: %v, want: %v", taken, tc.want)
+			}
+			if got := awards(l); len(got) != 1 || got["p0"] == 0 {
+				t.Errorf("awards: %v, want the pot to p0", got)
+			}
+		})
+	}
+}
+
+func TestTimeoutDeclines(t *testing.T) {
+	t.Run("Runouts", func(t *testing.T) {
+		var taken []player.ActionType
+		players := []*player.Player{
+			bot("p0", 100, prefer(player.ActionAllIn, player.ActionCall, player.ActionAcceptRunouts)),
+			bot("p1", 100, timingOut(&taken, prefer(player.ActionAllIn, player.ActionCall), player.ActionDeclineRunouts)),
+		}
+		r := deal(t, players, 0, WithRunouts(2), WithDeck(stacked(acesKings...)))
+
+		if want := []player.ActionType{player.ActionDeclineRunouts}; !slices.Equal(taken, want) {
+			t.Errorf("taken on timeout: %v, want: %v", taken, want)
+		}
+		if got, want := awards(r.ledger), map[string]int{"p0": 200}; !maps.Equal(got, want) {
+			t.Errorf("awards: %v, want the board run once: %v", got, want)
+		}
+	})
+
+	t.Run("Insurance", func(t *testing.T) {
+		var taken []player.ActionType
+		players := []*player.Player{
+			bot("p0", 100, prefer(player.ActionCall, player.ActionDeclineCashOut)),
+			bot("p1", 100, timingOut(&taken, shoving, player.ActionDeclineCashOut)),
+		}
+		r := deal(t, players, 0, WithInsurance(Insurance{Margin: 10}), WithDeck(stacked(acesKings...)))
+
+		if want := []player.ActionType{player.ActionDeclineCashOut}; !slices.Equal(taken, want) {
+			t.Errorf("taken on timeout: %v, want: %v", taken, want)
+		}
+		if len(r.Insured()) != 0 {
+			t.Errorf("insured: %v, want no one", r.Insured())
+		}
+	})
 }
@@ -0,0 +1,282 @@
+package round
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/dealer"
+	"github.com/yshngg/holdem/pkg/hand"
+	"github.com/yshngg/holdem/pkg/ledger"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/pot"
+	"github.com/yshngg/holdem/pkg/watch"
+)
+
+// WithRunouts offers to run the rest of the board up to the given times once the
+// players are all-in, e.g. 2 to run it twice. The board is run several times only
+// if every all-in player accepts, each pot is then split evenly between the boards.
+// It is run no more times than the cards left in the deck allow.
+func WithRunouts(runouts int) Option {
+	return func(r *Round) {
+		r.runouts = runouts
+	}
+}
+
+type ErrNoWinner struct{}
+
+func (e ErrNoWinner) Error() string {
+	return "no player to award the pot"
+}
+
+// allIn reports whether the betting is over before the last street: all the players
+// still in the hand are all-in, but at most one, and only the board is left to deal.
+func (r *Round) allIn() bool {
+	live, allIn := 0, 0
+	for _, p := range r.players {
+		switch p.Status() {
+		case player.StatusFolded:
+			continue
+		case player.StatusAllIn:
+			allIn++
+		}
+		live++
+	}
+	return live >= 2 && allIn > 0 && live-allIn <= 1 && r.boardOnly()
+}
+
+// runout deals the rest of the board, as many times as the all-in players agree to,
+// and shows the hands down on every board.
+func (r *Round) runout(ctx context.Context) error {
+	if r.insurance != nil {
+		if err := r.offerCashOuts(ctx); err != nil {
+			return fmt.Errorf("offer cash-outs, err: %w", err)
+		}
+	}
+	// the deck may run out of cards for several boards at a full table
+	runouts := min(r.runouts, len(r.dealer.Remaining())/r.boardCards())
+	times := 1
+	if runouts > 1 {
+		agreed, err := r.agreeRunouts(ctx)
+		if err != nil {
+			return fmt.Errorf("agree on runouts, err: %w", err)
+		}
+		if agreed {
+			times = runouts
+		}
+	}
+	if r.street == 0 {
+		r.flopPlayers = len(r.inHand())
+	}
+
+	// every board is dealt from the same deck, after the cards of the previous one
+	boards := make([][]*card.Card, 0, times)
+	for range times {
+		board, err := r.dealBoard(slices.Clone(r.communityCards))
+		if err != nil {
+			return err
+		}
+		boards = append(boards, board)
+		runoutEvent := NewEvent(EventRunout, r.Players(), board...)
+		if err := r.broadcaster.Action(runoutEvent); err != nil {
+			return fmt.Errorf("broadcast event: %v, err: %w", runoutEvent, err)
+		}
+	}
+	// the first runout is the board of the hand
+	r.communityCards = boards[0]
+	r.street = len(r.variant.Streets()) - 1
+	return r.showdown(boards)
+}
+
+// agreeRunouts asks the all-in players, from the left of the button, whether to run
+// the board several times. It stops at the first player who declines. The players
+// who have cashed out have no say.
+func (r *Round) agreeRunouts(ctx context.Context) (bool, error) {
+	available := []player.Action{
+		{Type: player.ActionAcceptRunouts},
+		{Type: player.ActionDeclineRunouts},
+	}
+	for i := range len(r.position) {
+		p, ok := r.players[r.position[(r.button+i+1)%len(r.position)]]
+		if !ok || p.Status() != player.StatusAllIn {
+			continue
+		}
+		if _, ok := r.insured[p.ID()]; ok {
+			continue
+		}
+		action, err := r.waitForAction(ctx, p, available)
+		if err != nil {
+			return false, fmt.Errorf("wait for player (id: %s) to take action, err: %w", p.ID(), err)
+		}
+		if action.Type != player.ActionAcceptRunouts {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// boardCards returns the number of cards to deal the board of the streets left, the
+// burn cards included.
+func (r *Round) boardCards() int {
+	count := 0
+	for _, street := range r.variant.Streets()[r.street+1:] {
+		count += 1 + street.Board
+	}
+	return count
+}
+
+// dealBoard deals the board of the streets left.
+func (r *Round) dealBoard(board []*card.Card) ([]*card.Card, error) {
+	for _, street := range r.variant.Streets()[r.street+1:] {
+		var err error
+		if board, err = r.dealCommunityCards(board, street.Board); err != nil {
+			return nil, err
+		}
+	}
+	return board, nil
+}
+
+// dealCommunityCards burns a card and deals the given number of community cards to
+// the board, the flop, the turn or the river.
+func (r *Round) dealCommunityCards(board []*card.Card, count int) ([]*card.Card, error) {
+	burnCard := r.dealer.BurnCard()
+	burnCardEvent := dealer.NewEvent(dealer.EventBurnCard, dealer.ToCommunity(), burnCard)
+	if err := r.broadcaster.Action(burnCardEvent); err != nil {
+		return nil, fmt.Errorf("broadcast event: %v, err: %w", burnCardEvent, err)
+	}
+
+	var dealCardsEvent watch.Event
+	switch {
+	case count == 3:
+		flopCards := r.dealer.DealFlopCards()
+		board = append(board, flopCards[:]...)
+		dealCardsEvent = dealer.NewEvent(dealer.EventDealFlopCards, dealer.ToCommunity(), flopCards[:]...)
+	case len(board) == 3:
+		turnCard := r.dealer.DealTurnCard()
+		board = append(board, turnCard)
+		dealCardsEvent = dealer.NewEvent(dealer.EventDealTurnCard, dealer.ToCommunity(), turnCard)
+	default:
+		riverCard := r.dealer.DealRiverCard()
+		board = append(board, riverCard)
+		dealCardsEvent = dealer.NewEvent(dealer.EventDealRiverCard, dealer.ToCommunity(), riverCard)
+	}
+	if err := r.broadcaster.Action(dealCardsEvent); err != nil {
+		return nil, fmt.Errorf("broadcast event: %v, err: %w", dealCardsEvent, err)
+	}
+	return board, nil
+}
+
+// showdown settles the pots and splits every pot evenly between the boards, the odd
+// chips going to the first boards. The share of a board is awarded to the best hands
+// on it, the odd chips going to the first winners from the left of the button. In
+// split-pot games the share is split between the high and the low hands. What the
+// players who have cashed out win in a contested pot goes to the insurer.
+func (r *Round) showdown(boards [][]*card.Card) error {
+	r.status = StatusShowdown
+	settled, err := r.settle()
+	if err != nil {
+		return err
+	}
+	for _, pot := range settled {
+		contenders := r.contenders(pot)
+		if len(contenders) == 0 {
+			// the chips of players who have all folded go to those left in the hand
+			contenders = r.inHand()
+		}
+		for i, board := range boards {
+			share := pot.Chips() / len(boards)
+			if i < pot.Chips()%len(boards) {
+				share++
+			}
+			high, low, err := r.split(contenders, board)
+			if err != nil {
+				return err
+			}
+			awards := pots.Split(share, high, low)
+			for _, p := range contenders {
+				id, chips := p.ID(), awards[p.ID()]
+				if chips == 0 {
+					continue
+				}
+				if _, ok := r.insured[id]; ok && len(contenders) > 1 {
+					if err := r.record(ledger.Award, ledger.Pot, ledger.Insurer, chips); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := r.award(id, chips); err != nil {
+					return fmt.Errorf("award player (id: %s), err: %w", id, err)
+				}
+			}
+		}
+	}
+
+	players := slices.DeleteFunc(r.Players(), func(p *player.Player) bool {
+		return p.Status() == player.StatusFolded
+	})
+	roundShowdownEvent := NewEvent(EventShowdown, players, r.communityCards...)
+	if err := r.broadcaster.Action(roundShowdownEvent); err != nil {
+		return fmt.Errorf("broadcast event: %v, err: %w", roundShowdownEvent, err)
+	}
+	return nil
+}
+
+// split returns the winners of the high and the low halves of a pot on the board.
+// The last contender wins it all, without showing the hand.
+func (r *Round) split(contenders []*player.Player, board []*card.Card) ([]string, []string, error) {
+	if len(contenders) == 1 {
+		return []string{contenders[0].ID()}, nil, nil
+	}
+	high, err := r.winners(r.variant.Evaluator(), contenders, board)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(high) == 0 {
+		return nil, nil, ErrNoWinner{}
+	}
+	var low []string
+	if evaluate := r.variant.Low(); evaluate != nil {
+		if low, err = r.winners(evaluate, contenders, board); err != nil {
+			return nil, nil, err
+		}
+	}
+	return high, low, nil
+}
+
+// contenders returns the contributors to the pot still in the hand, from the left
+// of the button.
+func (r *Round) contenders(pot pots.Pot) []*player.Player {
+	contenders := make([]*player.Player, 0, len(pot.Contributors()))
+	for i := range len(r.position) {
+		id := r.position[(r.button+i+1)%len(r.position)]
+		if _, ok := pot.Contributors()[id]; !ok {
+			continue
+		}
+		if p, ok := r.players[id]; ok && p.Status() != player.StatusFolded {
+			contenders = append(contenders, p)
+		}
+	}
+	return contenders
+}
+
+// winners returns the contenders with the best hand on the board, in order, none if
+// no one makes a hand, such as a qualifying low.
+func (r *Round) winners(evaluate hand.Evaluator, contenders []*player.Player, board []*card.Card) ([]string, error) {
+	var best hand.Score
+	winners := make([]string, 0)
+	for _, p := range contenders {
+		score, _, err := evaluate(r.cardsOf(p), values(board))
+		if err != nil {
+			return nil, fmt.Errorf("evaluate hand of player (id: %s), err: %w", p.ID(), err)
+		}
+		switch {
+		case score == 0:
+		case score > best:
+			best, winners = score, []string{p.ID()}
+		case score == best:
+			winners = append(winners, p.ID())
+		}
+	}
+	return winners, nil
+}
@@ -0,0 +1,146 @@
+package round
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+	"github.com/yshngg/holdem/pkg/variant"
+)
+
+// aces against kings heads-up, the kings make a set on the first board and the
+// aces hold on the second.
+var acesKings = []card.Card{
+	card.New(rank.Ace, suit.Spades), card.New(rank.King, suit.Spades),
+	card.New(rank.Ace, suit.Hearts), card.New(rank.King, suit.Hearts),
+	card.New(rank.Two, suit.Clubs), // burn
+	card.New(rank.King, suit.Diamonds), card.New(rank.Seven, suit.Clubs), card.New(rank.Three, suit.Hearts),
+	card.New(rank.Two, suit.Diamonds), // burn
+	card.New(rank.Nine, suit.Spades),
+	card.New(rank.Two, suit.Hearts), // burn
+	card.New(rank.Four, suit.Clubs),
+	card.New(rank.Five, suit.Spades), // burn
+	card.New(rank.Eight, suit.Diamonds), card.New(rank.Eight, suit.Clubs), card.New(rank.Jack, suit.Hearts),
+	card.New(rank.Five, suit.Hearts), // burn
+	card.New(rank.Queen, suit.Clubs),
+	card.New(rank.Five, suit.Diamonds), // burn
+	card.New(rank.Six, suit.Hearts),
+}
+
+func TestRunouts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		runouts  int
+		decision player.ActionType
+		boards   int
+		want     map[string]int
+	}{
+		{"Once", 1, player.ActionAcceptRunouts, 1, map[string]int{"p0": 200}},
+		{"Twice", 2, player.ActionAcceptRunouts, 2, map[string]int{"p0": 100, "p1": 100}},
+		{"Declined", 2, player.ActionDeclineRunouts, 1, map[string]int{"p0": 200}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			players := []*player.Player{
+				bot("p0", 100, prefer(player.ActionAllIn, player.ActionCall, player.ActionAcceptRunouts)),
+				bot("p1", 100, prefer(player.ActionAllIn, player.ActionCall, tc.decision)),
+			}
+			r := deal(t, players, 0, WithRunouts(tc.runouts), WithDeck(stacked(acesKings...)))
+
+			boards := 0
+			for _, event := range r.recorder.Events() {
+				if event.Kind() == EventKind && event.Action() == string(EventRunout) {
+					boards++
+				}
+			}
+			if boards != tc.boards {
+				t.Errorf("boards: %d, want: %d", boards, tc.boards)
+			}
+			if got := awards(r.ledger); !maps.Equal(got, tc.want) {
+				t.Errorf("awards: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHiLo(t *testing.T) {
+	// the aces of p1 win the high on both boards, the ace-deuce of p0 the low on the
+	// first one, there is no low on the second one
+	cards := []card.Card{
+		card.New(rank.Ace, suit.Spades), card.New(rank.Ace, suit.Clubs),
+		card.New(rank.Ace, suit.Hearts), card.New(rank.Two, suit.Clubs),
+		card.New(rank.King, suit.Spades), card.New(rank.Queen, suit.Diamonds),
+		card.New(rank.King, suit.Hearts), card.New(rank.Jack, suit.Diamonds),
+		card.New(rank.Four, suit.Clubs), // burn
+		card.New(rank.Seven, suit.Hearts), card.New(rank.Six, suit.Spades), card.New(rank.Five, suit.Diamonds),
+		card.New(rank.Four, suit.Diamonds), // burn
+		card.New(rank.Ten, suit.Spades),
+		card.New(rank.Four, suit.Hearts), // burn
+		card.New(rank.Nine, suit.Clubs),
+		card.New(rank.Three, suit.Clubs), // burn
+		card.New(rank.King, suit.Diamonds), card.New(rank.Queen, suit.Spades), card.New(rank.Nine, suit.Hearts),
+		card.New(rank.Three, suit.Diamonds), // burn
+		card.New(rank.Nine, suit.Spades),
+		card.New(rank.Three, suit.Hearts), // burn
+		card.New(rank.Two, suit.Hearts),
+	}
+	testCases := []struct {
+		name    string
+		runouts int
+		want    map[string]int
+	}{
+		{"Once", 1, map[string]int{"p0": 6, "p1": 6}},
+		{"Twice", 2, map[string]int{"p0": 3, "p1": 9}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			players := []*player.Player{
+				bot("p0", 6, prefer(player.ActionAllIn, player.ActionCall, player.ActionAcceptRunouts)),
+				bot("p1", 6, prefer(player.ActionAllIn, player.ActionCall, player.ActionAcceptRunouts)),
+			}
+			r := deal(t, players, 0, WithVariant(variant.OmahaHiLo), WithRunouts(tc.runouts), WithDeck(stacked(cards...)))
+			if got := awards(r.ledger); !maps.Equal(got, tc.want) {
+				t.Errorf("awards: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunoutsDeck(t *testing.T) {
+	// every board takes eight cards with the burn cards, a full table of Omaha leaves
+	// twelve in the deck, the players are all-in for the big blind
+	testCases := []struct {
+		name    string
+		players int
+		boards  int
+	}{
+		{"NinePlayers", 9, 2},
+		{"TenPlayers", 10, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			players := make([]*player.Player, 0, tc.players)
+			for i := range tc.players {
+				players = append(players, bot(fmt.Sprintf("p%d", i), 2, prefer(player.ActionAllIn, player.ActionCall, player.ActionAcceptRunouts)))
+			}
+			r := deal(t, players, 0, WithVariant(variant.Omaha), WithRunouts(3))
+
+			boards := 0
+			for _, event := range r.recorder.Events() {
+				if event.Kind() == EventKind && event.Action() == string(EventRunout) {
+					boards++
+				}
+			}
+			if boards != tc.boards {
+				t.Errorf("boards: %d, want: %d", boards, tc.boards)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package round
+
+// Straddle is a voluntary blind bet of twice the big blind, posted before the
+// hole cards are dealt. It buys the straddler the last action pre-flop.
+type Straddle int
+
+const (
+	StraddleNone   Straddle = iota
+	StraddleUTG             // posted by the player immediately to the left of the big blind
+	StraddleButton          // posted by the player on the button (Mississippi straddle)
+)
+
+func (s Straddle) String() string {
+	switch s {
+	case StraddleUTG:
+		return "UTG"
+	case StraddleButton:
+		return "Button"
+	default:
+		return "None"
+	}
+}
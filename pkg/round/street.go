@@ -0,0 +1,238 @@
+package round
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/dealer"
+	"github.com/yshngg/holdem/pkg/deck"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/variant"
+)
+
+// WithBringIn sets the forced bet of the lowest up card in stud, the small blind by default.
+func WithBringIn(bringIn int) Option {
+	return func(r *Round) {
+		r.bringIn = bringIn
+	}
+}
+
+// playStreet lets the players discard in pineapple, deals the street, lets the
+// players draw in draw games, and opens the betting round of the street.
+func (r *Round) playStreet(ctx context.Context, street variant.Street) error {
+	if street.Discard > 0 {
+		if err := r.discard(ctx, street.Discard); err != nil {
+			return fmt.Errorf("discard, err: %w", err)
+		}
+	}
+	if street.Hole > 0 || street.Up > 0 {
+		if err := r.dealPlayers(street); err != nil {
+			return err
+		}
+	}
+	if street.Board > 0 {
+		board, err := r.dealCommunityCards(r.communityCards, street.Board)
+		if err != nil {
+			return err
+		}
+		r.communityCards = board
+	}
+	if street.Draw {
+		if err := r.draw(ctx); err != nil {
+			return fmt.Errorf("draw, err: %w", err)
+		}
+	}
+
+	r.firstToAct = -1
+	inHand := r.inHand()
+	up := make([][]card.Card, 0, len(inHand))
+	for _, p := range inHand {
+		up = append(up, values(r.upCards[p.ID()]))
+	}
+	if first := r.variant.FirstToAct(r.street, up); first >= 0 {
+		seat := slices.Index(r.position, inHand[first].ID())
+		if r.street == 0 && r.variant.BringIn() {
+			if err := r.post(inHand[first].ID(), r.bringIn, player.EventPostBringIn, true); err != nil {
+				return fmt.Errorf("post bring-in: %w", err)
+			}
+			next, err := r.nextSeat(seat)
+			if err != nil {
+				return fmt.Errorf("bring-in position, err: %w", err)
+			}
+			seat = next
+		}
+		r.firstToAct = seat
+	}
+
+	if err := r.openBettingRound(ctx); err != nil {
+		return fmt.Errorf("open betting round: err: %w", err)
+	}
+	return nil
+}
+
+// dealPlayers deals the hole and up cards of the street to the players in the hand,
+// one card at a time from the left of the button. If the deck runs short, as in stud
+// with many players, one up card is dealt to the board for everyone instead.
+func (r *Round) dealPlayers(street variant.Street) error {
+	inHand := r.inHand()
+	count := street.Hole + street.Up
+	if len(r.dealer.Remaining()) < len(inHand)*count {
+		board, err := r.dealCommunityCards(r.communityCards, 1)
+		if err != nil {
+			return err
+		}
+		r.communityCards = board
+		return nil
+	}
+	cards := r.dealer.DealCards(len(inHand), count)
+	for i, p := range inHand {
+		hole, up := cards[i][:street.Hole], cards[i][street.Hole:]
+		if len(hole) > 0 {
+			p.SetHoleCards(append(p.HoleCards(), hole...))
+			dealHoleCardsEvent := dealer.NewEvent(dealer.EventDealHoleCards, dealer.ToPlayer(p), hole...)
+			if err := r.broadcaster.Action(dealHoleCardsEvent); err != nil {
+				return fmt.Errorf("broadcast event: %v, err: %w", dealHoleCardsEvent, err)
+			}
+		}
+		if len(up) > 0 {
+			r.upCards[p.ID()] = append(r.upCards[p.ID()], up...)
+			dealUpCardsEvent := dealer.NewUpCardsEvent(p.ID(), up...)
+			if err := r.broadcaster.Action(dealUpCardsEvent); err != nil {
+				return fmt.Errorf("broadcast event: %v, err: %w", dealUpCardsEvent, err)
+			}
+		}
+	}
+	return nil
+}
+
+// draw lets every player in the hand, from the left of the button, discard hole cards
+// and draw as many new ones. The discards are reshuffled into the deck once it runs out.
+func (r *Round) draw(ctx context.Context) error {
+	for _, p := range r.inHand() {
+		holeCards := p.HoleCards()
+		available := []player.Action{{Type: player.ActionDraw, Max: len(holeCards)}}
+		action, err := r.waitForAction(ctx, p, available)
+		if err != nil {
+			return fmt.Errorf("wait for player (id: %s) to take action, err: %w", p.ID(), err)
+		}
+
+		if len(r.dealer.Remaining()) < len(action.Discard) {
+			remaining := append(r.dealer.Remaining(), r.muck...)
+			r.dealer.SetDeck(deck.Of(remaining...))
+			r.dealer.Shuffle()
+			r.muck = nil
+			dealerShuffleEvent := dealer.NewEvent(dealer.EventShuffle, dealer.ToAll())
+			if err := r.broadcaster.Action(dealerShuffleEvent); err != nil {
+				return fmt.Errorf("broadcast event: %v, err: %w", dealerShuffleEvent, err)
+			}
+		}
+		drawn := make([]*card.Card, 0, len(action.Discard))
+		for _, i := range action.Discard {
+			r.muck = append(r.muck, *holeCards[i])
+			holeCards[i] = r.dealer.Deal()
+			drawn = append(drawn, holeCards[i])
+		}
+		p.SetHoleCards(holeCards)
+
+		drawEvent := player.NewEvent(player.EventDraw, player.EventObject{ID: p.ID(), Draw: len(drawn)})
+		if err := r.broadcaster.Action(drawEvent); err != nil {
+			return fmt.Errorf("broadcast event: %s, err: %w", player.EventDraw, err)
+		}
+		if len(drawn) == 0 {
+			continue
+		}
+		drawCardsEvent := dealer.NewEvent(dealer.EventDrawCards, dealer.ToPlayer(p), drawn...)
+		if err := r.broadcaster.Action(drawCardsEvent); err != nil {
+			return fmt.Errorf("broadcast event: %v, err: %w", drawCardsEvent, err)
+		}
+	}
+	return nil
+}
+
+// discard makes every player in the hand, from the left of the button, discard the
+// given number of hole cards. The last hole cards are discarded on timeout.
+func (r *Round) discard(ctx context.Context, count int) error {
+	for _, p := range r.inHand() {
+		holeCards := p.HoleCards()
+		suggested := make([]int, 0, count)
+		for i := len(holeCards) - count; i < len(holeCards); i++ {
+			suggested = append(suggested, i)
+		}
+		available := []player.Action{{Type: player.ActionDiscard, Max: count, Discard: suggested}}
+		action, err := r.waitForAction(ctx, p, available)
+		if err != nil {
+			return fmt.Errorf("wait for player (id: %s) to take action, err: %w", p.ID(), err)
+		}
+
+		kept := make([]*card.Card, 0, len(holeCards)-count)
+		discarded := make([]*card.Card, 0, count)
+		for i, c := range holeCards {
+			if slices.Contains(action.Discard, i) {
+				r.muck = append(r.muck, *c)
+				discarded = append(discarded, c)
+				continue
+			}
+			kept = append(kept, c)
+		}
+		p.SetHoleCards(kept)
+
+		discardEvent := player.NewEvent(player.EventDiscard, player.EventObject{ID: p.ID(), Discard: len(discarded)})
+		if err := r.broadcaster.Action(discardEvent); err != nil {
+			return fmt.Errorf("broadcast event: %s, err: %w", player.EventDiscard, err)
+		}
+		muckCardsEvent := dealer.NewEvent(dealer.EventMuckCards, dealer.ToPlayer(p), discarded...)
+		if err := r.broadcaster.Action(muckCardsEvent); err != nil {
+			return fmt.Errorf("broadcast event: %v, err: %w", muckCardsEvent, err)
+		}
+	}
+	return nil
+}
+
+// inHand returns the players who have not folded, from the left of the button.
+func (r *Round) inHand() []*player.Player {
+	inHand := make([]*player.Player, 0, len(r.players))
+	for i := range len(r.position) {
+		p, ok := r.players[r.position[(r.button+i+1)%len(r.position)]]
+		if ok && p.Status() != player.StatusFolded {
+			inHand = append(inHand, p)
+		}
+	}
+	return inHand
+}
+
+// cardsOf returns the cards of the player, down and up.
+func (r *Round) cardsOf(p *player.Player) []card.Card {
+	return append(values(p.HoleCards()), values(r.upCards[p.ID()])...)
+}
+
+// boardOnly reports whether the streets left deal community cards only, so the hand
+// can be run out once the players are all-in.
+func (r *Round) boardOnly() bool {
+	streets := r.variant.Streets()[r.street+1:]
+	if len(streets) == 0 {
+		return false
+	}
+	for _, street := range streets {
+		if street.Hole > 0 || street.Up > 0 || street.Discard > 0 || street.Draw {
+			return false
+		}
+	}
+	return true
+}
+
+// streetStatus returns the status of the street, the streets after the turn are
+// all played as the river.
+func streetStatus(street int) StatusType {
+	switch street {
+	case 0:
+		return StatusPreFlop
+	case 1:
+		return StatusFlop
+	case 2:
+		return StatusTurn
+	default:
+		return StatusRiver
+	}
+}
@@ -0,0 +1,174 @@
+package round
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/dealer"
+	"github.com/yshngg/holdem/pkg/ledger"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+	"github.com/yshngg/holdem/pkg/variant"
+)
+
+func TestStud(t *testing.T) {
+	// third street is dealt from the left of the button, two down and one up
+	d := stacked(
+		card.New(rank.Ace, suit.Spades), card.New(rank.Seven, suit.Clubs), card.New(rank.Eight, suit.Diamonds),
+		card.New(rank.Ace, suit.Hearts), card.New(rank.Seven, suit.Diamonds), card.New(rank.Eight, suit.Clubs),
+		card.New(rank.King, suit.Spades), card.New(rank.Two, suit.Clubs), card.New(rank.Nine, suit.Hearts),
+		// fourth street pairs the kings of p1
+		card.New(rank.King, suit.Hearts), card.New(rank.Three, suit.Clubs), card.New(rank.Ten, suit.Hearts),
+	)
+	players := []*player.Player{
+		bot("p0", 100, prefer(player.ActionCheck, player.ActionCall)),
+		bot("p1", 100, prefer(player.ActionCheck, player.ActionCall)),
+		bot("p2", 100, prefer(player.ActionCheck, player.ActionCall)),
+	}
+	r := deal(t, players, 0, WithVariant(variant.SevenCardStud), WithAnte(1), WithBringIn(1), WithDeck(d))
+
+	got := make([]posting, 0)
+	for _, tx := range r.ledger.Transactions() {
+		if tx.Kind == ledger.Ante || tx.Kind == ledger.Blind {
+			got = append(got, posting{tx.Kind, string(tx.From)[len("player:"):], tx.Chips})
+		}
+	}
+	// the deuce of p2 is the lowest up card and brings it in
+	want := []posting{{ledger.Ante, "p0", 1}, {ledger.Ante, "p1", 1}, {ledger.Ante, "p2", 1}, {ledger.Blind, "p2", 1}}
+	if !slices.Equal(got, want) {
+		t.Errorf("postings: %v, want: %v", got, want)
+	}
+
+	// third street is opened left of the bring-in, fourth street by the kings showing
+	if got, want := acted(r)[:6], []string{"p0", "p1", "p2", "p1", "p2", "p0"}; !slices.Equal(got, want) {
+		t.Errorf("acted: %v, want: %v", got, want)
+	}
+
+	up := make(map[string][]card.Card)
+	for _, event := range r.recorder.Events() {
+		if event.Kind() == dealer.EventKind && event.Action() == dealer.EventDealUpCards {
+			object := event.Related().(dealer.EventObject)
+			up[object.ID] = append(up[object.ID], values(object.Cards)...)
+		}
+	}
+	for _, p := range players {
+		if len(up[p.ID()]) != 4 {
+			t.Errorf("up cards of %s: %v, want one on each street but the last", p.ID(), up[p.ID()])
+		}
+	}
+	if want := []card.Card{card.New(rank.King, suit.Spades), card.New(rank.King, suit.Hearts)}; !slices.Equal(up["p1"][:2], want) {
+		t.Errorf("up cards of p1: %v, want: %v", up["p1"][:2], want)
+	}
+}
+
+// drawing draws for the hole cards at the indexes, and checks or calls.
+func drawing(indexes ...int) player.TimeoutPolicy {
+	return func(available []player.Action) (player.Action, error) {
+		if available[0].Type == player.ActionDraw {
+			return player.Action{Type: player.ActionDraw, Discard: indexes}, nil
+		}
+		return prefer(player.ActionCheck, player.ActionCall)(available)
+	}
+}
+
+// awards returns the chips awarded to each player in the ledger.
+func awards(l *ledger.Ledger) map[string]int {
+	awarded := make(map[string]int)
+	for _, tx := range l.Transactions() {
+		if tx.Kind == ledger.Award {
+			awarded[string(tx.To)[len("player:"):]] += tx.Chips
+		}
+	}
+	return awarded
+}
+
+// dealt returns the cards of the dealer events of the action, in order.
+func dealt(r *Round, action dealer.EventAction) []card.Card {
+	cards := make([]card.Card, 0)
+	for _, event := range r.recorder.Events() {
+		if event.Kind() == dealer.EventKind && event.Action() == action {
+			cards = append(cards, values(event.Related().(dealer.EventObject).Cards)...)
+		}
+	}
+	return cards
+}
+
+func TestDraw(t *testing.T) {
+	// the hole cards are dealt from the left of the button, then drawn for in order
+	d := stacked(
+		card.New(rank.Eight, suit.Spades), card.New(rank.King, suit.Spades),
+		card.New(rank.Six, suit.Hearts), card.New(rank.Seven, suit.Clubs),
+		card.New(rank.Five, suit.Clubs), card.New(rank.Five, suit.Diamonds),
+		card.New(rank.Four, suit.Clubs), card.New(rank.Three, suit.Hearts),
+		card.New(rank.Two, suit.Diamonds), card.New(rank.Two, suit.Spades),
+		card.New(rank.King, suit.Diamonds), card.New(rank.Queen, suit.Clubs), card.New(rank.Four, suit.Diamonds),
+	)
+	players := []*player.Player{
+		bot("p0", 100, drawing(0)),
+		bot("p1", 100, drawing()),
+	}
+	r := deal(t, players, 0, WithVariant(variant.TripleDraw27), WithDeck(d))
+
+	// p1 stands pat on an eight, p0 draws one three times to a seven
+	want := []card.Card{card.New(rank.King, suit.Diamonds), card.New(rank.Queen, suit.Clubs), card.New(rank.Four, suit.Diamonds)}
+	if got := dealt(r, dealer.EventDrawCards); !slices.Equal(got, want) {
+		t.Errorf("drawn: %v, want: %v", got, want)
+	}
+	draws := make(map[string]int)
+	for _, event := range r.recorder.Events() {
+		if event.Kind() == player.EventKind && event.Action() == string(player.EventDraw) {
+			object := event.Related().(player.EventObject)
+			draws[object.ID] += object.Draw
+		}
+	}
+	if want := map[string]int{"p0": 3, "p1": 0}; !maps.Equal(draws, want) {
+		t.Errorf("draws: %v, want: %v", draws, want)
+	}
+	if got, want := awards(r.ledger), map[string]int{"p0": 4}; !maps.Equal(got, want) {
+		t.Errorf("awards: %v, want: %v", got, want)
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	// three hole cards each, then a board of no help to anyone
+	cards := []card.Card{
+		card.New(rank.Ace, suit.Spades), card.New(rank.King, suit.Spades),
+		card.New(rank.Ace, suit.Hearts), card.New(rank.King, suit.Hearts),
+		card.New(rank.Two, suit.Clubs), card.New(rank.Three, suit.Clubs),
+		card.New(rank.Four, suit.Diamonds), // burn
+		card.New(rank.Nine, suit.Clubs), card.New(rank.Eight, suit.Diamonds), card.New(rank.Four, suit.Hearts),
+		card.New(rank.Six, suit.Diamonds), // burn
+		card.New(rank.Jack, suit.Clubs),
+		card.New(rank.Six, suit.Spades), // burn
+		card.New(rank.Ten, suit.Spades),
+	}
+	testCases := []struct {
+		name    string
+		variant variant.Variant
+	}{
+		{"Pineapple", variant.Pineapple},
+		{"CrazyPineapple", variant.CrazyPineapple},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			players := []*player.Player{
+				bot("p0", 100, prefer(player.ActionCheck, player.ActionCall)),
+				bot("p1", 100, prefer(player.ActionCheck, player.ActionCall)),
+			}
+			r := deal(t, players, 0, WithVariant(tc.variant), WithDeck(stacked(cards...)))
+
+			// the last hole card is discarded on timeout, and the aces hold
+			want := []card.Card{card.New(rank.Two, suit.Clubs), card.New(rank.Three, suit.Clubs)}
+			if got := dealt(r, dealer.EventMuckCards); !slices.Equal(got, want) {
+				t.Errorf("mucked: %v, want: %v", got, want)
+			}
+			if got, want := awards(r.ledger), map[string]int{"p1": 4}; !maps.Equal(got, want) {
+				t.Errorf("awards: %v, want: %v", got, want)
+			}
+		})
+	}
+}
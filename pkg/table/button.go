@@ -0,0 +1,117 @@
+package table
+
+import "fmt"
+
+// blindPositions indicates the seats of the dealer button and the blinds in a hand.
+//
+// The blinds follow the dead button rule: the big blind moves forward exactly one
+// seat dealt in every hand, the small blind takes the seat of the previous big blind
+// and the button takes the seat of the previous small blind, even if those seats
+// have been vacated in the meantime (dead small blind, dead button). Thereby no one
+// can dodge the blinds by leaving or joining the table.
+type blindPositions struct {
+	button, small, big int
+}
+
+// noBlindPositions means no hand has been played at the table yet.
+var noBlindPositions = blindPositions{button: -1, small: -1, big: -1}
+
+type ErrNotEnoughPlayers struct {
+	count int
+}
+
+func (e ErrNotEnoughPlayers) Error() string {
+	return fmt.Sprintf("not enough players to start a hand: %d", e.count)
+}
+
+// nextBigBlind returns the seat of the big blind in the next hand,
+// ready reports the seats which have a player ready to play.
+func (b blindPositions) nextBigBlind(ready []bool) int {
+	if b.big >= 0 {
+		return nextSeat(b.big, ready)
+	}
+	// the first hand, the button starts from the first ready seat
+	button := nextSeat(len(ready)-1, ready)
+	if button < 0 {
+		return -1
+	}
+	if countSeats(ready) == 2 {
+		return nextSeat(button, ready)
+	}
+	return nextSeat(nextSeat(button, ready), ready)
+}
+
+// next returns the positions in the next hand, given the seat of the big blind and the
+// seats dealt in. A small blind or button which is not dealt in is dead.
+func (b blindPositions) next(big int, dealt []bool) (blindPositions, error) {
+	count := countSeats(dealt)
+	if count < MinPlayerCount || big < 0 || !dealt[big] {
+		return noBlindPositions, ErrNotEnoughPlayers{count: count}
+	}
+	if count == 2 {
+		// heads-up, the button posts the small blind and acts first pre-flop
+		button := nextSeat(big, dealt)
+		return blindPositions{button: button, small: button, big: big}, nil
+	}
+	if b.big < 0 {
+		small := previousSeat(big, dealt)
+		return blindPositions{button: previousSeat(small, dealt), small: small, big: big}, nil
+	}
+
+	next := blindPositions{button: b.small, small: b.big, big: big}
+	if next.button == next.small || next.button == next.big {
+		// coming from heads-up, where the button has posted the small blind,
+		// the button moves to the seat right before the small blind
+		next.button = (next.small - 1 + len(dealt)) % len(dealt)
+		if next.button == next.big {
+			next.button = previousSeat(next.small, dealt)
+		}
+	}
+	return next, nil
+}
+
+// nextSeat returns the first marked seat after the given seat, -1 if there is none.
+func nextSeat(seat int, seats []bool) int {
+	length := len(seats)
+	for i := range length {
+		next := (seat + i + 1) % length
+		if seats[next] {
+			return next
+		}
+	}
+	return -1
+}
+
+// previousSeat returns the first marked seat before the given seat, -1 if there is none.
+func previousSeat(seat int, seats []bool) int {
+	length := len(seats)
+	for i := range length {
+		previous := ((seat-i-1)%length + length) % length
+		if seats[previous] {
+			return previous
+		}
+	}
+	return -1
+}
+
+// passedSeats returns the seats strictly between from and to, going clockwise.
+func passedSeats(from, to, length int) []int {
+	seats := make([]int, 0)
+	if from < 0 || to < 0 {
+		return seats
+	}
+	for i := (from + 1) % length; i != to; i = (i + 1) % length {
+		seats = append(seats, i)
+	}
+	return seats
+}
+
+func countSeats(seats []bool) int {
+	count := 0
+	for _, ok := range seats {
+		if ok {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,218 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/round"
+)
+
+func TestBlindPositionsNext(t *testing.T) {
+	testCases := []struct {
+		name  string
+		prev  blindPositions
+		ready []bool
+		dealt []bool
+		want  blindPositions
+		err   error
+	}{
+		{
+			name:  "FirstHand",
+			prev:  noBlindPositions,
+			ready: []bool{true, true, true, true},
+			dealt: []bool{true, true, true, true},
+			want:  blindPositions{button: 0, small: 1, big: 2},
+		},
+		{
+			name:  "FirstHandHeadsUp",
+			prev:  noBlindPositions,
+			ready: []bool{false, true, false, true},
+			dealt: []bool{false, true, false, true},
+			want:  blindPositions{button: 1, small: 1, big: 3},
+		},
+		{
+			name:  "MoveForward",
+			prev:  blindPositions{button: 0, small: 1, big: 2},
+			ready: []bool{true, true, true, true},
+			dealt: []bool{true, true, true, true},
+			want:  blindPositions{button: 1, small: 2, big: 3},
+		},
+		{
+			name:  "DeadButton",
+			prev:  blindPositions{button: 0, small: 1, big: 2},
+			ready: []bool{true, false, true, true},
+			dealt: []bool{true, false, true, true},
+			want:  blindPositions{button: 1, small: 2, big: 3},
+		},
+		{
+			name:  "DeadSmallBlind",
+			prev:  blindPositions{button: 0, small: 1, big: 2},
+			ready: []bool{true, true, false, true},
+			dealt: []bool{true, true, false, true},
+			want:  blindPositions{button: 1, small: 2, big: 3},
+		},
+		{
+			name:  "SkipAwaySeat",
+			prev:  blindPositions{button: 0, small: 1, big: 2},
+			ready: []bool{true, true, true, false, true},
+			dealt: []bool{true, true, true, false, true},
+			want:  blindPositions{button: 1, small: 2, big: 4},
+		},
+		{
+			name:  "ShrinkToHeadsUp",
+			prev:  blindPositions{button: 0, small: 1, big: 2},
+			ready: []bool{false, true, true},
+			dealt: []bool{false, true, true},
+			want:  blindPositions{button: 2, small: 2, big: 1},
+		},
+		{
+			name:  "GrowFromHeadsUp",
+			prev:  blindPositions{button: 2, small: 2, big: 1},
+			ready: []bool{true, true, true},
+			dealt: []bool{true, true, true},
+			want:  blindPositions{button: 0, small: 1, big: 2},
+		},
+		{
+			name:  "WaitForBigBlind",
+			prev:  blindPositions{button: 0, small: 1, big: 2},
+			ready: []bool{true, true, true, true, true},
+			dealt: []bool{true, true, true, true, false},
+			want:  blindPositions{button: 1, small: 2, big: 3},
+		},
+		{
+			name:  "NotEnoughPlayers",
+			prev:  blindPositions{button: 0, small: 1, big: 2},
+			ready: []bool{false, false, true},
+			dealt: []bool{false, false, true},
+			want:  noBlindPositions,
+			err:   ErrNotEnoughPlayers{count: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			big := tc.prev.nextBigBlind(tc.ready)
+			got, err := tc.prev.next(big, tc.dealt)
+			if err != tc.err {
+				t.Errorf("err: %v, want: %v", err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("positions: %+v, want: %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPassedSeats(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from, to int
+		length   int
+		want     []int
+	}{
+		{"Adjacent", 1, 2, 4, []int{}},
+		{"Forward", 0, 3, 4, []int{1, 2}},
+		{"Wrap", 2, 1, 4, []int{3, 0}},
+		{"Unset", -1, 1, 4, []int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := passedSeats(tc.from, tc.to, tc.length)
+			if len(got) != len(tc.want) {
+				t.Fatalf("passed seats: %v, want: %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("passed seats: %v, want: %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPostMissedBlinds(t *testing.T) {
+	testCases := []struct {
+		name      string
+		id        string
+		missed    round.MissedBlinds
+		positions blindPositions
+		want      []player.EventAction
+	}{
+		{
+			// dave sits out at the big blind's next seat, the big blind skips him
+			name:      "BigBlind",
+			id:        "dave",
+			missed:    round.MissedBlinds{Small: true, Big: true},
+			positions: blindPositions{button: 2, small: 0, big: 1},
+			want:      []player.EventAction{player.EventPostDeadSmallBlind, player.EventPostMissedBigBlind},
+		},
+		{
+			// carol sits out after the big blind, her small blind is dead and she
+			// returns on the dead button
+			name:      "DeadButton",
+			id:        "carol",
+			missed:    round.MissedBlinds{Small: true},
+			positions: blindPositions{button: 2, small: 3, big: 0},
+			want:      []player.EventAction{player.EventPostDeadSmallBlind},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			table := New(WithCapacity(4), WithActionClock(round.Clock{Base: time.Millisecond}))
+			players := join(t, table, 100, "alice", "bob", "carol", "dave")
+			events := watchEvents(players[0])
+			for _, p := range players[1:] {
+				watchEvents(p)
+			}
+
+			if err := table.PlayHand(t.Context()); err != nil {
+				t.Fatalf("play hand, err: %v", err)
+			}
+			if err := table.SitOut(tc.id); err != nil {
+				t.Fatalf("sit out, err: %v", err)
+			}
+			if err := table.PlayHand(t.Context()); err != nil {
+				t.Fatalf("play hand, err: %v", err)
+			}
+			if missed, _ := table.MissedBlinds(tc.id); missed != tc.missed {
+				t.Fatalf("missed blinds of %s: %+v, want: %+v", tc.id, missed, tc.missed)
+			}
+
+			if err := table.SitIn(tc.id); err != nil {
+				t.Fatalf("sit in, err: %v", err)
+			}
+			if err := table.PostMissedBlinds(tc.id); err != nil {
+				t.Fatalf("post missed blinds, err: %v", err)
+			}
+			if err := table.PlayHand(t.Context()); err != nil {
+				t.Fatalf("play hand, err: %v", err)
+			}
+			for _, p := range players {
+				p.StopWatch()
+			}
+
+			if table.positions != tc.positions {
+				t.Errorf("positions: %+v, want: %+v", table.positions, tc.positions)
+			}
+			if _, owing := table.MissedBlinds(tc.id); owing {
+				t.Errorf("%s still owes the blinds", tc.id)
+			}
+			posted := make([]player.EventAction, 0)
+			for _, event := range <-events {
+				switch action := player.EventAction(event.Action()); action {
+				case player.EventPostDeadSmallBlind, player.EventPostMissedBigBlind:
+					if event.Related().(player.EventObject).ID == tc.id {
+						posted = append(posted, action)
+					}
+				}
+			}
+			if !reflect.DeepEqual(posted, tc.want) {
+				t.Errorf("posted by %s: %v, want: %v", tc.id, posted, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package table
+
+import (
+	"time"
+
+	"github.com/yshngg/holdem/pkg/watch"
+)
+
+type EventAction string
+
+const (
+	EventKind string = "table"
+
+	EventSitAndGoStart EventAction = "SitAndGoStart"
+	EventLevelUp       EventAction = "LevelUp"
+	EventEliminate     EventAction = "Eliminate"
+	EventPayout        EventAction = "Payout"
+	EventRebuy         EventAction = "Rebuy"
+	EventTopUp         EventAction = "TopUp"
+	EventSeatChange    EventAction = "SeatChange"
+	EventSeatOpen      EventAction = "SeatOpen"
+)
+
+type EventObject struct {
+	// ID is the player the event is about, if any.
+	ID string
+
+	// Level is the index of the current blind level.
+	Level int
+
+	// Place is the finishing position of an eliminated or paid player.
+	Place int
+
+	// Prize is the amount paid to the player.
+	Prize int
+
+	// Chips is the chips bought by the player.
+	Chips int
+
+	// Seat is the seat the player has moved to, or the seat left open.
+	Seat int
+}
+
+type Event struct {
+	action    EventAction
+	object    EventObject
+	eventTime time.Time
+}
+
+func NewEvent(action EventAction, object EventObject) watch.Event {
+	return Event{
+		action:    action,
+		object:    object,
+		eventTime: time.Now(),
+	}
+}
+
+func (e Event) Kind() string {
+	return EventKind
+}
+
+func (e Event) Action() string {
+	return string(e.action)
+}
+
+func (e Event) Related() any {
+	return e.object
+}
+
+func (e Event) Time() time.Time {
+	return e.eventTime
+}
+
+var _ watch.Event = Event{}
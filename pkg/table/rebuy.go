@@ -0,0 +1,113 @@
+package table
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yshngg/holdem/pkg/ledger"
+	"k8s.io/klog/v2"
+)
+
+type ErrInvalidBuyIn struct {
+	chips, min, max int
+}
+
+func (e ErrInvalidBuyIn) Error() string {
+	if e.max <= 0 {
+		return fmt.Sprintf("invalid buy-in: %d, min: %d", e.chips, e.min)
+	}
+	return fmt.Sprintf("invalid buy-in: %d, min: %d, max: %d", e.chips, e.min, e.max)
+}
+
+type ErrRebuyNotAllowed struct{}
+
+func (e ErrRebuyNotAllowed) Error() string {
+	return "rebuy is not allowed"
+}
+
+// WithMaxBuyIn limits the stack a player can buy in or top up to, zero means no limit.
+func WithMaxBuyIn(max int) Option {
+	return func(t *Table) {
+		t.maxBuyIn = max
+	}
+}
+
+// checkBuyIn checks the stack a player would have after buying chips.
+func (t *Table) checkBuyIn(stack int) error {
+	if stack < t.threshold || (t.maxBuyIn > 0 && stack > t.maxBuyIn) {
+		return ErrInvalidBuyIn{chips: stack, min: t.threshold, max: t.maxBuyIn}
+	}
+	return nil
+}
+
+// TopUp buys chips for the player, a busted player rebuys. The chips are added
+// between hands, and the stack must stay within the buy-in limits.
+func (t *Table) TopUp(id string, chips int) error {
+	if t.sitAndGo != nil {
+		return ErrRebuyNotAllowed{}
+	}
+	p, exists := t.players[id]
+	if !exists {
+		return ErrPlayerNotFound{id: id}
+	}
+	if chips <= 0 {
+		return ErrInvalidBuyIn{chips: chips, min: t.threshold, max: t.maxBuyIn}
+	}
+	if err := t.checkBuyIn(p.Chips() + t.buying[id] + chips); err != nil {
+		return err
+	}
+	t.buying[id] += chips
+	return nil
+}
+
+// AddChips credits chips paid for away from the table, such as the rebuys and add-ons
+// of a tournament, to the player's stack and to the ledger.
+func (t *Table) AddChips(ctx context.Context, id string, kind ledger.Kind, chips int) error {
+	p, exists := t.players[id]
+	if !exists {
+		return ErrPlayerNotFound{id: id}
+	}
+	if _, err := t.ledger.BuyIn(ctx, nil, id, kind, chips, 0); err != nil {
+		return fmt.Errorf("add chips, err: %w", err)
+	}
+	p.AddChips(chips)
+	return nil
+}
+
+// Purchases returns every chip purchase made at the table, in order.
+func (t *Table) Purchases() []ledger.Purchase {
+	return t.purchases.Records("")
+}
+
+// buyChips adds the chips bought during the last hand. The purchases the wallets
+// cannot pay for are dropped.
+func (t *Table) buyChips(ctx context.Context) error {
+	defer clear(t.buying)
+	for id, chips := range t.buying {
+		p, exists := t.players[id]
+		if !exists {
+			continue
+		}
+		if t.maxBuyIn > 0 {
+			// the stack might have grown during the hand
+			chips = min(chips, t.maxBuyIn-p.Chips())
+		}
+		if chips <= 0 {
+			continue
+		}
+		kind, action := ledger.TopUp, EventTopUp
+		if p.Chips() == 0 {
+			kind, action = ledger.Rebuy, EventRebuy
+		}
+		if _, err := t.ledger.BuyIn(ctx, t.wallet, id, kind, chips, chips); err != nil {
+			klog.ErrorS(err, "buy chips", "id", id, "chips", chips)
+			continue
+		}
+		p.AddChips(chips)
+		t.purchases.Record(id, kind, chips, chips)
+		if err := t.announce(action, EventObject{ID: id, Chips: chips}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
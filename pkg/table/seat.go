@@ -0,0 +1,129 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/round"
+)
+
+// AnySeat picks the first open seat.
+const AnySeat = -1
+
+type ErrTableFull struct{}
+
+func (e ErrTableFull) Error() string {
+	return "no seat is open"
+}
+
+type ErrInvalidSeat struct {
+	seat int
+}
+
+func (e ErrInvalidSeat) Error() string {
+	return fmt.Sprintf("invalid seat: %d", e.seat)
+}
+
+type ErrSeatTaken struct {
+	seat int
+}
+
+func (e ErrSeatTaken) Error() string {
+	return fmt.Sprintf("seat %d is taken", e.seat)
+}
+
+// Seat is a seat at the table, as seen by the UIs.
+type Seat struct {
+	Index int
+
+	// ID and Name are the occupant of the seat, empty if the seat is open.
+	ID   string
+	Name string
+
+	Chips  int
+	Status player.StatusType
+
+	// Button indicates the seat had the dealer button in the last hand.
+	Button bool
+}
+
+// Seats returns every seat of the table, in order.
+func (t *Table) Seats() []Seat {
+	seats := make([]Seat, len(t.position))
+	for i, id := range t.position {
+		seats[i] = Seat{Index: i, Button: i == t.positions.button}
+		if id == nil {
+			continue
+		}
+		if _, left := t.left[*id]; left {
+			continue
+		}
+		p := t.players[*id]
+		seats[i].ID = p.ID()
+		seats[i].Name = p.Name()
+		seats[i].Chips = p.Chips()
+		seats[i].Status = p.Status()
+	}
+	return seats
+}
+
+// seatChange is a request of a seated player to move to another seat.
+type seatChange struct {
+	id   string
+	seat int
+}
+
+// RequestSeatChange asks to move the player to the seat, or to any other open seat
+// if it is AnySeat. The requests are honored between hands in the order they are made,
+// as soon as the seat opens. A later request of the player replaces the earlier one.
+func (t *Table) RequestSeatChange(id string, seat int) error {
+	if _, exists := t.players[id]; !exists {
+		return ErrPlayerNotFound{id: id}
+	}
+	if seat != AnySeat && (seat < 0 || seat >= len(t.position)) {
+		return ErrInvalidSeat{seat: seat}
+	}
+	t.CancelSeatChange(id)
+	t.seatChanges = append(t.seatChanges, seatChange{id: id, seat: seat})
+	return nil
+}
+
+func (t *Table) CancelSeatChange(id string) {
+	t.seatChanges = slices.DeleteFunc(t.seatChanges, func(change seatChange) bool {
+		return change.id == id
+	})
+}
+
+// changeSeats moves the players to the seats they have requested, if they are open.
+// A player moving seats in a running game waits for the big blind, or posts it,
+// so the blinds cannot be dodged by changing seats.
+func (t *Table) changeSeats() error {
+	pending := make([]seatChange, 0, len(t.seatChanges))
+	for _, change := range t.seatChanges {
+		from := slices.IndexFunc(t.position, func(id *string) bool {
+			return id != nil && *id == change.id
+		})
+		if from < 0 {
+			// the player has left
+			continue
+		}
+		to := change.seat
+		if to == AnySeat {
+			to = slices.Index(t.position, nil)
+		}
+		if to < 0 || t.position[to] != nil {
+			pending = append(pending, change)
+			continue
+		}
+		t.position[to], t.position[from] = t.position[from], nil
+		if t.positions.big >= 0 && !t.dealNewPlayers {
+			t.missed[change.id] = round.MissedBlinds{Big: true}
+		}
+		if err := t.announce(EventSeatChange, EventObject{ID: change.id, Seat: to}); err != nil {
+			return err
+		}
+	}
+	t.seatChanges = pending
+	return nil
+}
@@ -0,0 +1,104 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yshngg/holdem/pkg/round"
+)
+
+// occupants returns the IDs of the players in every seat, empty for an open seat.
+func occupants(table *Table) []string {
+	ids := make([]string, 0, table.Capacity())
+	for _, seat := range table.Seats() {
+		ids = append(ids, seat.ID)
+	}
+	return ids
+}
+
+func TestJoinAt(t *testing.T) {
+	testCases := []struct {
+		name string
+		seat int
+		want []string
+		err  error
+	}{
+		{name: "OpenSeat", seat: 2, want: []string{"", "alice", "bob"}},
+		{name: "AnySeat", seat: AnySeat, want: []string{"bob", "alice", ""}},
+		{name: "Taken", seat: 1, want: []string{"", "alice", ""}, err: ErrSeatTaken{}},
+		{name: "Negative", seat: -2, want: []string{"", "alice", ""}, err: ErrInvalidSeat{}},
+		{name: "OutOfTable", seat: 3, want: []string{"", "alice", ""}, err: ErrInvalidSeat{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			table := New(WithCapacity(3))
+			if _, err := table.JoinAt(t.Context(), 1, "alice", "alice", 100); err != nil {
+				t.Fatalf("join table, err: %v", err)
+			}
+			_, err := table.JoinAt(t.Context(), tc.seat, "bob", "bob", 100)
+			if reflect.TypeOf(err) != reflect.TypeOf(tc.err) {
+				t.Fatalf("JoinAt().err = %v, want %T", err, tc.err)
+			}
+			if got := occupants(table); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("seats: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRequestSeatChange(t *testing.T) {
+	table := New(WithCapacity(4), WithActionClock(round.Clock{Base: time.Millisecond}))
+	for _, p := range join(t, table, 100, "alice", "bob", "carol") {
+		watchEvents(p)
+	}
+
+	testCases := []struct {
+		name string
+		id   string
+		seat int
+		err  error
+	}{
+		{name: "NotSeated", id: "dave", seat: 3, err: ErrPlayerNotFound{}},
+		{name: "Negative", id: "alice", seat: -2, err: ErrInvalidSeat{}},
+		{name: "OutOfTable", id: "alice", seat: 4, err: ErrInvalidSeat{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := table.RequestSeatChange(tc.id, tc.seat); reflect.TypeOf(err) != reflect.TypeOf(tc.err) {
+				t.Errorf("RequestSeatChange().err = %v, want %T", err, tc.err)
+			}
+		})
+	}
+
+	if err := table.PlayHand(t.Context()); err != nil {
+		t.Fatalf("play hand, err: %v", err)
+	}
+	// bob asks for the seat of alice first, and waits for alice to move away
+	if err := table.RequestSeatChange("bob", 0); err != nil {
+		t.Fatalf("request seat change, err: %v", err)
+	}
+	if err := table.RequestSeatChange("alice", 3); err != nil {
+		t.Fatalf("request seat change, err: %v", err)
+	}
+	if err := table.changeSeats(); err != nil {
+		t.Fatalf("change seats, err: %v", err)
+	}
+	if got, want := occupants(table), []string{"", "bob", "carol", "alice"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("seats: %v, want: %v", got, want)
+	}
+	if err := table.changeSeats(); err != nil {
+		t.Fatalf("change seats, err: %v", err)
+	}
+	if got, want := occupants(table), []string{"bob", "", "carol", "alice"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("seats: %v, want: %v", got, want)
+	}
+
+	// moving in a running game, the blinds are not dodged
+	for _, id := range []string{"alice", "bob"} {
+		if missed, _ := table.MissedBlinds(id); !missed.Big {
+			t.Errorf("missed blinds of %s: %+v, want the big blind owed", id, missed)
+		}
+	}
+}
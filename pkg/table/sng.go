@@ -0,0 +1,244 @@
+package table
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/yshngg/holdem/pkg/blind"
+	"github.com/yshngg/holdem/pkg/payout"
+	"github.com/yshngg/holdem/pkg/player"
+)
+
+// SitAndGo configures a single table tournament, which starts as soon as enough
+// players have joined, and ends once a single player holds all the chips.
+type SitAndGo struct {
+	// Players is the number of players the game starts with,
+	// if it is invalid, the game starts with a full table.
+	Players int
+
+	// StartingStack is the chips every player starts with, it must be positive.
+	StartingStack int
+
+	// BuyIn is what every player pays to join, it must be positive.
+	BuyIn int
+
+	// Schedule is the blind levels, lasting for a time or a number of hands.
+	Schedule blind.Schedule
+
+	// PrizePool is the total amount paid out.
+	PrizePool int
+
+	// Payouts are the percentages of the prize pool paid by place.
+	Payouts payout.Structure
+}
+
+// Result is the finishing position of a player in a sit & go.
+type Result struct {
+	ID    string
+	Name  string
+	Place int
+	Prize int
+}
+
+// sitAndGo is the state of a running sit & go.
+type sitAndGo struct {
+	SitAndGo
+
+	// full is closed once all players have joined, no one can join from then on.
+	full    chan struct{}
+	started bool
+
+	progress *blind.Progress
+
+	// stacks are the chips the players started the last hand with.
+	stacks map[string]int
+
+	// results are the finishing positions, from the last place.
+	results []Result
+}
+
+// WithSitAndGo turns the table into a sit & go. Players join with the starting stack
+// whatever chips they bring, and cannot rebuy.
+func WithSitAndGo(sng SitAndGo) Option {
+	return func(t *Table) {
+		t.sitAndGo = &sitAndGo{
+			SitAndGo: sng,
+			full:     make(chan struct{}),
+		}
+	}
+}
+
+// ErrInvalidSitAndGo means the sit & go has a starting stack or a buy-in which is
+// not positive.
+type ErrInvalidSitAndGo struct {
+	startingStack, buyIn int
+}
+
+func (e ErrInvalidSitAndGo) Error() string {
+	return fmt.Sprintf("invalid sit & go, starting stack: %d, buy-in: %d", e.startingStack, e.buyIn)
+}
+
+type ErrSitAndGoStarted struct{}
+
+func (e ErrSitAndGoStarted) Error() string {
+	return "sit & go has started"
+}
+
+// ErrSitAndGoUnfinished means the hands stopped while several players still hold chips,
+// such as when they are sitting out.
+type ErrSitAndGoUnfinished struct {
+	count int
+}
+
+func (e ErrSitAndGoUnfinished) Error() string {
+	return fmt.Sprintf("sit & go has not finished, players left: %d", e.count)
+}
+
+// Results returns the finishing positions of the sit & go so far, from the first place.
+func (t *Table) Results() []Result {
+	if t.sitAndGo == nil {
+		return nil
+	}
+	results := slices.Clone(t.sitAndGo.results)
+	slices.Reverse(results)
+	return results
+}
+
+func (sng SitAndGo) validate() error {
+	if sng.StartingStack <= 0 || sng.BuyIn <= 0 {
+		return ErrInvalidSitAndGo{startingStack: sng.StartingStack, buyIn: sng.BuyIn}
+	}
+	return nil
+}
+
+// register closes the registration once the sit & go is full.
+func (sng *sitAndGo) register(count int) {
+	if sng.started || count < sng.Players {
+		return
+	}
+	sng.started = true
+	close(sng.full)
+}
+
+// startSitAndGo waits for all the players to join, and deals them in.
+func (t *Table) startSitAndGo(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.sitAndGo.full:
+	}
+	for id, p := range t.players {
+		if p.Status() != player.StatusIdle {
+			continue
+		}
+		if err := p.Ready(); err != nil {
+			return fmt.Errorf("ready player (id: %s), err: %w", id, err)
+		}
+	}
+	t.sitAndGo.progress = blind.NewProgress(t.sitAndGo.Schedule, time.Now())
+	index, level := t.sitAndGo.progress.Level()
+	t.SetLevel(level)
+	return t.announce(EventSitAndGoStart, EventObject{Level: index})
+}
+
+// beforeSitAndGoHand raises the blinds if the level is up, records the stacks the
+// players start the hand with, and deals in the players sitting out, who post the
+// blinds and time out on every decision until they are busted.
+func (t *Table) beforeSitAndGoHand() error {
+	if err := t.advanceLevel(0); err != nil {
+		return err
+	}
+	t.sitAndGo.stacks = make(map[string]int, len(t.players))
+	for _, p := range t.Players() {
+		t.sitAndGo.stacks[p.ID()] = p.Chips()
+		if p.Status() != player.StatusSittingOut || p.Chips() == 0 {
+			continue
+		}
+		if err := p.DealIn(); err != nil {
+			return fmt.Errorf("deal in player (id: %s), err: %w", p.ID(), err)
+		}
+	}
+	return nil
+}
+
+// afterSitAndGoHand eliminates the players who have no chips left.
+func (t *Table) afterSitAndGoHand() error {
+	if err := t.advanceLevel(1); err != nil {
+		return err
+	}
+	busted := make([]string, 0)
+	for _, p := range t.Players() {
+		if p.Chips() == 0 {
+			busted = append(busted, p.ID())
+		}
+	}
+	places := payout.Places(busted, t.sitAndGo.stacks, len(t.sitAndGo.stacks))
+	slices.SortStableFunc(busted, func(a, b string) int {
+		return places[b] - places[a]
+	})
+	for _, id := range busted {
+		t.sitAndGo.results = append(t.sitAndGo.results, Result{
+			ID:    id,
+			Name:  t.players[id].Name(),
+			Place: places[id],
+		})
+		t.leave(id)
+		if err := t.announce(EventEliminate, EventObject{ID: id, Place: places[id]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Table) advanceLevel(hands int) error {
+	before, _ := t.sitAndGo.progress.Level()
+	index, level := t.sitAndGo.progress.Advance(time.Now(), hands)
+	if index == before {
+		return nil
+	}
+	t.SetLevel(level)
+	return t.announce(EventLevelUp, EventObject{Level: index})
+}
+
+// finishSitAndGo records the winner and pays out the prizes.
+func (t *Table) finishSitAndGo(ctx context.Context) error {
+	remaining := slices.DeleteFunc(t.Players(), func(p *player.Player) bool {
+		return p.Chips() == 0
+	})
+	if len(remaining) != 1 {
+		return ErrSitAndGoUnfinished{count: len(remaining)}
+	}
+	winner := remaining[0]
+	t.sitAndGo.results = append(t.sitAndGo.results, Result{ID: winner.ID(), Name: winner.Name(), Place: 1})
+	places := make([]int, len(t.sitAndGo.results))
+	for i, result := range t.sitAndGo.results {
+		places[i] = result.Place
+	}
+	prizes := t.sitAndGo.Payouts.Prizes(t.sitAndGo.PrizePool, places)
+	for i := len(t.sitAndGo.results) - 1; i >= 0; i-- {
+		result := &t.sitAndGo.results[i]
+		result.Prize = prizes[i]
+		if result.Prize == 0 {
+			continue
+		}
+		if t.wallet != nil {
+			if err := t.wallet.Credit(ctx, result.ID, result.Prize); err != nil {
+				return fmt.Errorf("pay prize to player (id: %s), err: %w", result.ID, err)
+			}
+		}
+		object := EventObject{ID: result.ID, Place: result.Place, Prize: result.Prize}
+		if err := t.announce(EventPayout, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Table) announce(action EventAction, object EventObject) error {
+	if err := t.broadcaster.Action(NewEvent(action, object)); err != nil {
+		return fmt.Errorf("broadcast event: %s, err: %w", action, err)
+	}
+	return nil
+}
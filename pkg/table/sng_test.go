@@ -0,0 +1,280 @@
+package table
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/yshngg/holdem/pkg/blind"
+	"github.com/yshngg/holdem/pkg/ledger"
+	"github.com/yshngg/holdem/pkg/payout"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/round"
+)
+
+func TestEliminationPlaces(t *testing.T) {
+	testCases := []struct {
+		name      string
+		busted    []string
+		stacks    map[string]int
+		remaining int
+		want      map[string]int
+	}{
+		{
+			name:      "One",
+			busted:    []string{"a"},
+			stacks:    map[string]int{"a": 100},
+			remaining: 6,
+			want:      map[string]int{"a": 6},
+		},
+		{
+			name:      "BiggerStackPlacesBetter",
+			busted:    []string{"a", "b"},
+			stacks:    map[string]int{"a": 100, "b": 300},
+			remaining: 6,
+			want:      map[string]int{"a": 6, "b": 5},
+		},
+		{
+			name:      "Tie",
+			busted:    []string{"a", "b"},
+			stacks:    map[string]int{"a": 200, "b": 200},
+			remaining: 3,
+			want:      map[string]int{"a": 2, "b": 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := payout.Places(tc.busted, tc.stacks, tc.remaining)
+			for id, place := range tc.want {
+				if got[id] != place {
+					t.Errorf("place of %s: %d, want: %d", id, got[id], place)
+				}
+			}
+		})
+	}
+}
+
+func TestPrizes(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pool    int
+		payouts payout.Structure
+		places  []int
+		want    []int
+	}{
+		{
+			name:    "WinnerTakesAll",
+			pool:    100,
+			payouts: payout.Structure{100},
+			places:  []int{3, 2, 1},
+			want:    []int{0, 0, 100},
+		},
+		{
+			name:    "TopThree",
+			pool:    1000,
+			payouts: payout.Structure{50, 30, 20},
+			places:  []int{6, 5, 4, 3, 2, 1},
+			want:    []int{0, 0, 0, 200, 300, 500},
+		},
+		{
+			name:    "RoundingToWinner",
+			pool:    101,
+			payouts: payout.Structure{65, 35},
+			places:  []int{2, 1},
+			want:    []int{35, 66},
+		},
+		{
+			name:    "TieSplitsPlaces",
+			pool:    1000,
+			payouts: payout.Structure{50, 30, 20},
+			places:  []int{2, 2, 1},
+			want:    []int{250, 250, 500},
+		},
+		{
+			name:    "TieOddChip",
+			pool:    100,
+			payouts: payout.Structure{60, 25, 15},
+			places:  []int{4, 3, 3, 2, 1},
+			want:    []int{0, 8, 7, 25, 60},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.payouts.Prizes(tc.pool, tc.places)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("prizes: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestJoinSitAndGo(t *testing.T) {
+	testCases := []struct {
+		name string
+		sng  SitAndGo
+		want error
+	}{
+		{name: "Valid", sng: SitAndGo{Players: 2, StartingStack: 1500, BuyIn: 10}},
+		{name: "NoStartingStack", sng: SitAndGo{Players: 2, BuyIn: 10}, want: ErrInvalidSitAndGo{}},
+		{name: "NegativeStartingStack", sng: SitAndGo{Players: 2, StartingStack: -1500, BuyIn: 10}, want: ErrInvalidSitAndGo{}},
+		{name: "NoBuyIn", sng: SitAndGo{Players: 2, StartingStack: 1500}, want: ErrInvalidSitAndGo{}},
+		{name: "NegativeBuyIn", sng: SitAndGo{Players: 2, StartingStack: 1500, BuyIn: -10}, want: ErrInvalidSitAndGo{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			table := New(WithCapacity(2), WithSitAndGo(tc.sng))
+			p, err := table.Join(t.Context(), "alice", "alice", 100)
+			if reflect.TypeOf(err) != reflect.TypeOf(tc.want) {
+				t.Fatalf("Join().err = %v, want %T", err, tc.want)
+			}
+			if err == nil && p.Chips() != tc.sng.StartingStack {
+				t.Errorf("chips: %d, want the starting stack of %d", p.Chips(), tc.sng.StartingStack)
+			}
+		})
+	}
+}
+
+// allIn goes all-in, or calls all-in, whenever it can.
+func allIn(available []player.Action) (player.Action, error) {
+	for _, actionType := range []player.ActionType{player.ActionAllIn, player.ActionCall} {
+		for _, action := range available {
+			if action.Type == actionType {
+				return action, nil
+			}
+		}
+	}
+	return player.CheckOrFold(available)
+}
+
+// playOut plays the sit & go started to the end as Start does, without waiting
+// between the hands.
+func playOut(t *testing.T, table *Table) {
+	t.Helper()
+	for hands := 0; ; hands++ {
+		if hands == 100 {
+			t.Fatalf("sit & go unfinished after %d hands", hands)
+		}
+		err := table.PlayHand(t.Context())
+		var notEnoughPlayers ErrNotEnoughPlayers
+		if errors.As(err, &notEnoughPlayers) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("play hand, err: %v", err)
+		}
+	}
+	if err := table.finishSitAndGo(t.Context()); err != nil {
+		t.Fatalf("finish sit & go, err: %v", err)
+	}
+}
+
+func TestSitAndGo(t *testing.T) {
+	ids := []string{"alice", "bob", "carol"}
+	wallet := ledger.NewMemoryWallet(map[string]int{"alice": 10, "bob": 10, "carol": 10})
+	table := New(
+		WithCapacity(3),
+		WithWallet(wallet),
+		WithSitAndGo(SitAndGo{
+			Players:       3,
+			StartingStack: 100,
+			BuyIn:         10,
+			Schedule:      blind.Schedule{{SmallBlind: 5, BigBlind: 10}},
+			PrizePool:     30,
+			Payouts:       payout.Structure{70, 30},
+		}),
+		WithActionClock(round.Clock{Base: time.Millisecond}),
+		// everyone is all-in every hand, until a single player holds all the chips
+		WithTimeoutPolicy(allIn),
+	)
+
+	// register
+	for _, id := range ids {
+		p, err := table.Join(t.Context(), id, id, 0)
+		if err != nil {
+			t.Fatalf("join table, err: %v", err)
+		}
+		watchEvents(p)
+		if balance, _ := wallet.Balance(t.Context(), id); balance != 0 || p.Chips() != 100 {
+			t.Errorf("%s has %d chips and %d in the wallet, want the starting stack for the buy-in", id, p.Chips(), balance)
+		}
+	}
+	if _, err := table.Join(t.Context(), "dave", "dave", 0); err == nil {
+		t.Errorf("join a full sit & go, want an error")
+	}
+
+	if err := table.startSitAndGo(t.Context()); err != nil {
+		t.Fatalf("start sit & go, err: %v", err)
+	}
+	playOut(t, table)
+
+	// finishing places and prizes
+	results := table.Results()
+	if len(results) != len(ids) {
+		t.Fatalf("results: %v, want a place for every player", results)
+	}
+	winner := results[0]
+	if winner.Place != 1 || winner.Prize != 21 {
+		t.Errorf("winner: %v, want the first place paid 70%% of the pool", winner)
+	}
+	if players := table.Players(); len(players) != 1 || players[0].ID() != winner.ID || players[0].Chips() != 300 {
+		t.Errorf("players left: %v, want the winner holding all the chips", players)
+	}
+	switch second, third := results[1], results[2]; {
+	case second.Place == 2 && third.Place == 3:
+		if second.Prize != 9 || third.Prize != 0 {
+			t.Errorf("prizes: %d and %d, want 30%% of the pool for the second place only", second.Prize, third.Prize)
+		}
+	case second.Place == 2 && third.Place == 2:
+		// busted in the same hand from the same stack, the second place is shared
+		if second.Prize+third.Prize != 9 || max(second.Prize, third.Prize)-min(second.Prize, third.Prize) > 1 {
+			t.Errorf("prizes: %d and %d, want the second place split", second.Prize, third.Prize)
+		}
+	default:
+		t.Errorf("places: %d and %d, want the second and the third", second.Place, third.Place)
+	}
+	for _, result := range results {
+		if balance, _ := wallet.Balance(t.Context(), result.ID); balance != result.Prize {
+			t.Errorf("wallet of %s: %d, want the prize of %d", result.ID, balance, result.Prize)
+		}
+	}
+}
+
+func TestSitAndGoLeave(t *testing.T) {
+	table := New(
+		WithCapacity(3),
+		WithSitAndGo(SitAndGo{Players: 3, StartingStack: 100, BuyIn: 10, PrizePool: 30, Payouts: payout.Structure{70, 30}}),
+		WithActionClock(round.Clock{Base: time.Millisecond}),
+		WithTimeoutPolicy(allIn),
+	)
+	for _, id := range []string{"alice", "bob", "carol"} {
+		p, err := table.Join(t.Context(), id, id, 0)
+		if err != nil {
+			t.Fatalf("join table, err: %v", err)
+		}
+		watchEvents(p)
+	}
+	if err := table.startSitAndGo(t.Context()); err != nil {
+		t.Fatalf("start sit & go, err: %v", err)
+	}
+
+	// carol leaves once the sit & go has started, and is dealt in until busted
+	if err := table.Leave(t.Context(), "carol"); err != nil {
+		t.Fatalf("leave, err: %v", err)
+	}
+	playOut(t, table)
+	results := table.Results()
+	if len(results) != 3 {
+		t.Fatalf("results: %v, want a place for every player", results)
+	}
+	dealt := slices.ContainsFunc(table.Ledger().Transactions(), func(tx ledger.Transaction) bool {
+		return tx.From == ledger.PlayerAccount("carol")
+	})
+	if !dealt {
+		t.Errorf("carol has not bet, want the blinds or the bets of the hands dealt")
+	}
+}
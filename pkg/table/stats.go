@@ -0,0 +1,40 @@
+package table
+
+import "github.com/yshngg/holdem/pkg/round"
+
+// Stats are the statistics of the hands played at a table, shown in the lobby.
+type Stats struct {
+	Hands int
+
+	// Pots is the sum of the pots of all hands.
+	Pots int
+
+	// Dealt is the sum of the players dealt in every hand.
+	Dealt int
+
+	// Flops is the sum of the players who have seen the flop in every hand.
+	Flops int
+}
+
+func (s *Stats) record(r *round.Round) {
+	s.Hands++
+	s.Pots += r.Pot()
+	s.Dealt += r.PlayerCount()
+	s.Flops += r.FlopPlayers()
+}
+
+// AveragePot returns the average chips bet in a hand.
+func (s Stats) AveragePot() float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return float64(s.Pots) / float64(s.Hands)
+}
+
+// PlayersPerFlop returns the percentage of the players dealt in who see the flop.
+func (s Stats) PlayersPerFlop() float64 {
+	if s.Dealt == 0 {
+		return 0
+	}
+	return float64(s.Flops) * 100 / float64(s.Dealt)
+}
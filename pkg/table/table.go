@@ -2,14 +2,20 @@ package table
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
+	"sync"
 	"time"
 
+	"github.com/yshngg/holdem/pkg/blind"
 	"github.com/yshngg/holdem/pkg/card"
 	"github.com/yshngg/holdem/pkg/dealer"
+	"github.com/yshngg/holdem/pkg/ledger"
 	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/pot"
 	"github.com/yshngg/holdem/pkg/round"
+	"github.com/yshngg/holdem/pkg/variant"
 	"github.com/yshngg/holdem/pkg/watch"
 	"k8s.io/klog/v2"
 )
@@ -24,8 +30,17 @@ const (
 )
 
 type Table struct {
+	// hand is held while a hand is played, the players join between the hands.
+	hand sync.Mutex
+
 	round *round.Round
 
+	// hands is the number of hands played at the table.
+	hands int
+
+	// dealer deals all hands at the table.
+	dealer *dealer.Dealer
+
 	// left indicates the players who left the table.
 	// Need to remove from the Round after the round is finished.
 	left map[string]struct{}
@@ -37,6 +52,9 @@ type Table struct {
 	// position indicates the position relationship among players on the table.
 	position []*string
 
+	// seatChanges are the requests of seated players to move, in the order they are made.
+	seatChanges []seatChange
+
 	// waiting indicates the players who are waiting to join the table.
 	// waiting is a FIFO queue.
 	waiting []string
@@ -47,14 +65,97 @@ type Table struct {
 	// minBet is minimum bet on the table.
 	minBet int
 
+	// smallBlind and bigBlind are the blind amounts, zero means derived from minBet.
+	smallBlind, bigBlind int
+
+	// ante is posted by every player, bigBlindAnte by the big blind and buttonAnte by
+	// the button for the whole table.
+	ante, bigBlindAnte, buttonAnte int
+
+	// straddle indicates who posts an optional straddle every hand.
+	straddle round.Straddle
+
+	// positions are the seats of the button and the blinds in the last hand.
+	positions blindPositions
+
+	// missed holds the blinds missed by the players who were away, they have to post
+	// them or wait for the big blind to be dealt in again.
+	// Players who join the table after the first hand owe a big blind.
+	missed map[string]round.MissedBlinds
+
+	// posting indicates the players who choose to post missed blinds in the next hand,
+	// instead of waiting for the big blind.
+	posting map[string]struct{}
+
+	// autoFoldTimeouts is the number of consecutive timeouts after which a player
+	// folds at once and is sat out after the hand, zero disables it.
+	autoFoldTimeouts int
+
+	// maxOrbitsAway is the number of orbits a player can sit out before being
+	// removed from the table, zero disables it.
+	maxOrbitsAway int
+
+	// away counts the orbits (big blinds passed) each sitting out player has missed.
+	away map[string]int
+
+	// dealNewPlayers deals players joining a running game in at once, instead of
+	// having them owe a big blind, as tournaments do.
+	dealNewPlayers bool
+
+	// sitAndGo is the sit & go the table runs, nil for a cash game.
+	sitAndGo *sitAndGo
+
 	// player need at least `threshold` chips to join.
 	// threshold must greater than minBet.
 	// if `threshold <= 0`, the value will be `minBet * 4`.
 	threshold int
 
+	// maxBuyIn is the largest stack a player can buy in or top up to, zero means no limit.
+	maxBuyIn int
+
+	// buying holds the chips bought during a hand, added once it ends.
+	buying map[string]int
+
+	// purchases records every chip purchase.
+	purchases *ledger.Purchases
+
+	// ledger records every chip moved at the table.
+	ledger *ledger.Ledger
+
+	// wallet is the players' bankroll, debited by buy-ins and credited by cash-outs.
+	wallet ledger.Wallet
+
+	// rakePolicy is how the house takes a share of the pots in cash games.
+	rakePolicy pots.RakePolicy
+
+	// rakes records the rake taken from every hand.
+	rakes *ledger.Rakes
+
+	// variant is the game dealt at the table, limit overrides its betting structure.
+	variant variant.Variant
+	limit   *variant.Limit
+
+	// runouts is the most times the board is run when the players are all-in.
+	runouts int
+
+	// insurance offers the all-in players to cash out their equity.
+	insurance *round.Insurance
+
+	stats Stats
+
 	// actionTimeout indicates how long can player take actions
 	actionTimeout time.Duration
 
+	// clock is the action clock, its base time defaults to actionTimeout.
+	clock round.Clock
+
+	// timeBank configures the players' time banks.
+	timeBank TimeBank
+
+	// timeoutPolicy picks the action taken on behalf of players who do not act in time.
+	// if nil, it checks when possible and folds otherwise.
+	timeoutPolicy player.TimeoutPolicy
+
 	watcher watch.Interface
 
 	broadcaster watch.Broadcaster
@@ -67,6 +168,9 @@ func New(opts ...Option) *Table {
 	}
 	if t.minBet == 0 {
 		t.minBet = defaultMinBet
+		if t.bigBlind > 0 {
+			t.minBet = t.bigBlind
+		}
 	}
 	if t.capacity < MinPlayerCount || t.capacity > MaxPlayerCount {
 		t.capacity = defaultCapacity
@@ -78,10 +182,37 @@ func New(opts ...Option) *Table {
 	if t.actionTimeout <= 0 {
 		t.actionTimeout = defaultActionTimeout
 	}
+	if t.clock.Base <= 0 {
+		t.clock.Base = t.actionTimeout
+	}
+	if t.timeoutPolicy == nil {
+		t.timeoutPolicy = player.CheckOrFold
+	}
+	if t.sitAndGo != nil {
+		if t.sitAndGo.Players < MinPlayerCount || t.sitAndGo.Players > t.capacity {
+			t.sitAndGo.Players = t.capacity
+		}
+		// players sitting out are blinded, never removed
+		t.maxOrbitsAway = 0
+		// the house takes its fee from the buy-ins
+		t.rakePolicy = pots.RakePolicy{}
+		t.insurance = nil
+	}
 	t.players = make(map[string]*player.Player, t.capacity)
-	t.position = make([]*string, 0, t.capacity)
+	t.position = make([]*string, t.capacity)
 	t.waiting = make([]string, 0, t.capacity)
 	t.left = make(map[string]struct{}, 0)
+	t.positions = noBlindPositions
+	t.missed = make(map[string]round.MissedBlinds)
+	t.posting = make(map[string]struct{})
+	t.away = make(map[string]int)
+	t.dealer = dealer.New()
+	t.buying = make(map[string]int)
+	t.purchases = ledger.NewPurchases()
+	t.rakes = ledger.NewRakes()
+	if t.ledger == nil {
+		t.ledger = ledger.New()
+	}
 	queueLength := t.capacity * 2
 	t.broadcaster = watch.NewBroadcaster(queueLength, queueLength)
 	watcher, err := t.broadcaster.Watch()
@@ -89,17 +220,106 @@ func New(opts ...Option) *Table {
 		panic(err)
 	}
 	t.watcher = watcher
+	go t.logEvents()
 	return t
 }
 
 type Option func(t *Table)
 
+// WithLedger records the chips moved at the table in the given ledger, which might be
+// shared by several tables.
+func WithLedger(l *ledger.Ledger) Option {
+	return func(t *Table) {
+		t.ledger = l
+	}
+}
+
+// WithWallet makes the buy-ins debit and the cash-outs credit the players' wallets.
+func WithWallet(wallet ledger.Wallet) Option {
+	return func(t *Table) {
+		t.wallet = wallet
+	}
+}
+
+// Ledger returns the chip ledger of the table.
+func (t *Table) Ledger() *ledger.Ledger {
+	return t.ledger
+}
+
+// WithRake makes the house take a share of every pot, it is ignored by sit & go.
+func WithRake(policy pots.RakePolicy) Option {
+	return func(t *Table) {
+		t.rakePolicy = policy
+	}
+}
+
+// WithVariant deals the given game at the table instead of hold'em.
+func WithVariant(v variant.Variant) Option {
+	return func(t *Table) {
+		t.variant = v
+	}
+}
+
+// WithLimit overrides the betting structure the variant is usually played with.
+func WithLimit(limit variant.Limit) Option {
+	return func(t *Table) {
+		t.limit = &limit
+	}
+}
+
+// WithRunouts lets all-in players run the rest of the board up to the given times.
+func WithRunouts(runouts int) Option {
+	return func(t *Table) {
+		t.runouts = runouts
+	}
+}
+
+// WithInsurance offers all-in insurance, it is ignored by sit & go.
+func WithInsurance(insurance round.Insurance) Option {
+	return func(t *Table) {
+		t.insurance = &insurance
+	}
+}
+
 func WithMinBet(minBet int) Option {
 	return func(t *Table) {
 		t.minBet = minBet
 	}
 }
 
+func WithBlinds(small, big int) Option {
+	return func(t *Table) {
+		t.smallBlind = small
+		t.bigBlind = big
+	}
+}
+
+func WithAnte(ante int) Option {
+	return func(t *Table) {
+		t.ante = ante
+	}
+}
+
+func WithBigBlindAnte(ante int) Option {
+	return func(t *Table) {
+		t.bigBlindAnte = ante
+	}
+}
+
+// WithButtonAnte makes the button post an ante for the whole table every hand,
+// the structure short deck is usually played with.
+func WithButtonAnte(ante int) Option {
+	return func(t *Table) {
+		t.buttonAnte = ante
+	}
+}
+
+func WithStraddle(straddle round.Straddle) Option {
+	return func(t *Table) {
+		t.straddle = straddle
+	}
+}
+
 func WithCapacity(capacity int) Option {
 	return func(t *Table) {
 		t.capacity = capacity
@@ -118,11 +338,147 @@ func WithActionTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithActionClock sets the base time for every decision, with per street overrides.
+func WithActionClock(clock round.Clock) Option {
+	return func(t *Table) {
+		t.clock = clock
+	}
+}
+
+// TimeBank configures the extra time players can spend once the action clock runs out.
+type TimeBank struct {
+	// Initial is the time bank of a player joining the table.
+	Initial time.Duration
+
+	// Replenish is added to every player's time bank each Every hands, up to Max.
+	Replenish time.Duration
+	Every     int
+	Max       time.Duration
+}
+
+func WithTimeBank(timeBank TimeBank) Option {
+	return func(t *Table) {
+		t.timeBank = timeBank
+	}
+}
+
+func WithTimeoutPolicy(policy player.TimeoutPolicy) Option {
+	return func(t *Table) {
+		t.timeoutPolicy = policy
+	}
+}
+
+func WithAutoFoldTimeouts(timeouts int) Option {
+	return func(t *Table) {
+		t.autoFoldTimeouts = timeouts
+	}
+}
+
+func WithMaxOrbitsAway(orbits int) Option {
+	return func(t *Table) {
+		t.maxOrbitsAway = orbits
+	}
+}
+
+func WithNewPlayersDealtIn() Option {
+	return func(t *Table) {
+		t.dealNewPlayers = true
+	}
+}
+
+// SetLevel changes the blinds and antes from the next hand on.
+func (t *Table) SetLevel(level blind.Level) {
+	t.smallBlind = level.SmallBlind
+	t.bigBlind = level.BigBlind
+	t.ante = level.Ante
+	t.bigBlindAnte = level.BigBlindAnte
+	if level.BigBlind > 0 {
+		t.minBet = level.BigBlind
+	}
+}
+
+// Players returns the players seated at the table, by seats.
+func (t *Table) Players() []*player.Player {
+	players := make([]*player.Player, 0, len(t.position))
+	for _, id := range t.position {
+		if id == nil {
+			continue
+		}
+		if _, left := t.left[*id]; left {
+			continue
+		}
+		players = append(players, t.players[*id])
+	}
+	return players
+}
+
+// Rakes returns the rake taken from every hand played at the table, in order.
+func (t *Table) Rakes() []ledger.HandRake {
+	return t.rakes.Records()
+}
+
+func (t *Table) Capacity() int {
+	return t.capacity
+}
+
+// Blinds returns the current small and big blinds.
+func (t *Table) Blinds() (small, big int) {
+	big = t.bigBlind
+	if big <= 0 {
+		big = t.minBet
+	}
+	small = t.smallBlind
+	if small <= 0 {
+		small = big / 2
+	}
+	return small, big
+}
+
+// Stats returns the statistics of the hands played at the table.
+func (t *Table) Stats() Stats {
+	return t.stats
+}
+
 func (t *Table) PlayerCount() int {
 	return len(t.players) - len(t.left)
 }
 
-func (t *Table) Join(name, id string, chips int) (*player.Player, error) {
+// OpenSeats returns the number of seats open to join, it waits for the hand being
+// played to end. The seats of players who have left are open once the hand ends.
+func (t *Table) OpenSeats() int {
+	t.hand.Lock()
+	defer t.hand.Unlock()
+	open := 0
+	for _, id := range t.position {
+		if id == nil {
+			open++
+		} else if _, left := t.left[*id]; left {
+			open++
+		}
+	}
+	return open
+}
+
+// Watch returns the events of the table, such as the seats opening.
+func (t *Table) Watch() (watch.Interface, error) {
+	return t.broadcaster.Watch()
+}
+
+// Join seats the player at the first open seat.
+func (t *Table) Join(ctx context.Context, name, id string, chips int) (*player.Player, error) {
+	return t.JoinAt(ctx, AnySeat, name, id, chips)
+}
+
+// JoinAt seats the player at the given seat, or at the first open seat if it is AnySeat.
+// It waits for the hand being played to end.
+func (t *Table) JoinAt(ctx context.Context, seat int, name, id string, chips int) (*player.Player, error) {
+	t.hand.Lock()
+	defer t.hand.Unlock()
+	// no hand is played, the players who have left are released
+	if err := t.clean(ctx); err != nil {
+		return nil, err
+	}
+
 	// exists := slices.ContainsFunc(t.waiting, func(pp *player.Player) bool {
 	// 	return p.ID() == pp.ID()
 	// })
@@ -135,7 +491,25 @@ func (t *Table) Join(name, id string, chips int) (*player.Player, error) {
 	if t.PlayerCount() >= t.capacity {
 		return nil, fmt.Errorf("have reached the capacity of table")
 	}
+	cost := chips
+	if t.sitAndGo != nil {
+		if t.sitAndGo.started {
+			return nil, ErrSitAndGoStarted{}
+		}
+		if err := t.sitAndGo.validate(); err != nil {
+			return nil, err
+		}
+		chips, cost = t.sitAndGo.StartingStack, t.sitAndGo.BuyIn
+	} else if err := t.checkBuyIn(chips); err != nil {
+		return nil, err
+	}
+	seat, err := t.openSeat(seat)
+	if err != nil {
+		return nil, err
+	}
 
+	// p is used by the filter of the watcher, the player is created with it
+	var p *player.Player
 	watcher, err := t.broadcaster.Watch()
 	if err != nil {
 		return nil, fmt.Errorf("watch broadcaster, err: %w", err)
@@ -143,41 +517,69 @@ func (t *Table) Join(name, id string, chips int) (*player.Player, error) {
 	watcher = watch.Filter(watcher, func(in watch.Event) (out watch.Event, keep bool) {
 		// TODO(@yshngg): filter showdown event
 		// TODO(@yshngg): hiden burned card (dealer)
-		// hole cards are only visable to player own them
+		// the cards dealt to a player, hole cards, drawn or mucked, are only
+		// visable to player own them
 		if in.Kind() == dealer.EventKind {
 			dealerEvent := in.(dealer.Event)
 			dealerEventObject := dealerEvent.Related().(dealer.EventObject)
-			if dealerEvent.Action() != dealer.EventDealHoleCards || dealerEventObject.To == dealer.ToPlayer(p) {
+			switch dealerEventObject.To {
+			case dealer.ToAll(), dealer.ToCommunity(), dealer.ToPlayer(p):
 				return in, true
 			}
-			// zero other player's hole cards
+			// zero other player's cards
 			cards := make([]*card.Card, len(dealerEventObject.Cards))
-			dealer.NewEvent(dealerEvent.Action(), dealerEventObject.To, cards...)
+			return dealer.NewEvent(dealerEvent.Action(), dealerEventObject.To, cards...), true
 		}
 		return in, true
 	})
 
-	p := player.New(
+	p = player.New(
 		player.WithName(name),
 		player.WithID(id),
 		player.WithChips(chips),
 		player.WithWatcher(watcher),
 		player.WithActionTimeout(t.actionTimeout),
+		player.WithAutoFold(t.autoFoldTimeouts),
+		player.WithTimeBank(t.timeBank.Initial),
+		player.WithTimeoutPolicy(t.timeoutPolicy),
 	)
+	if _, err := t.ledger.BuyIn(ctx, t.wallet, p.ID(), ledger.BuyIn, chips, cost); err != nil {
+		watcher.Stop()
+		return nil, fmt.Errorf("buy in, err: %w", err)
+	}
 
 	t.players[p.ID()] = p
 	t.waiting = append(t.waiting, p.ID())
-	t.sitDown(p.ID())
+	t.purchases.Record(p.ID(), ledger.BuyIn, chips, cost)
+	if t.positions.big >= 0 && !t.dealNewPlayers {
+		// joining a running game, post a big blind or wait for it
+		t.missed[p.ID()] = round.MissedBlinds{Big: true}
+	}
+	pid := p.ID()
+	t.position[seat] = &pid
+	if t.sitAndGo != nil {
+		t.sitAndGo.register(t.PlayerCount())
+	}
 	return p, nil
 }
 
-func (t *Table) sitDown(id string) {
-	for i, idPtr := range t.position {
-		if idPtr != nil {
-			continue
+// openSeat checks the seat is open, or finds the first open seat if it is AnySeat.
+// Seats of players who have left are open once the hand ends.
+func (t *Table) openSeat(seat int) (int, error) {
+	if seat == AnySeat {
+		seat = slices.Index(t.position, nil)
+		if seat < 0 {
+			return -1, ErrTableFull{}
 		}
-		t.position[i] = &id
+		return seat, nil
 	}
+	if seat < 0 || seat >= len(t.position) {
+		return -1, ErrInvalidSeat{seat: seat}
+	}
+	if t.position[seat] != nil {
+		return -1, ErrSeatTaken{seat: seat}
+	}
+	return seat, nil
 }
 
 type ErrPlayerNotFound struct {
@@ -188,108 +590,391 @@ func (e ErrPlayerNotFound) Error() string {
 	return fmt.Sprintf("player (id: %s) did not sit at the table", e.id)
 }
 
-func (t *Table) Leave(ctx context.Context, id string) error {
+type ErrNoMissedBlinds struct {
+	id string
+}
+
+func (e ErrNoMissedBlinds) Error() string {
+	return fmt.Sprintf("player (id: %s) does not owe any blinds", e.id)
+}
+
+// MissedBlinds returns the blinds the player owes, a player owing blinds is dealt in
+// only after posting them or when the big blind reaches the seat.
+func (t *Table) MissedBlinds(id string) (round.MissedBlinds, bool) {
+	missed, ok := t.missed[id]
+	return missed, ok
+}
+
+// PostMissedBlinds makes the player post the missed blinds and be dealt in the next
+// hand, instead of waiting for the big blind. A missed big blind is live, a missed
+// small blind is dead.
+func (t *Table) PostMissedBlinds(id string) error {
+	if _, exists := t.players[id]; !exists {
+		return ErrPlayerNotFound{id: id}
+	}
+	if _, owing := t.missed[id]; !owing {
+		return ErrNoMissedBlinds{id: id}
+	}
+	t.posting[id] = struct{}{}
+	return nil
+}
+
+// SitOut keeps the player's seat and chips, but skips the player in dealing from
+// the next hand on. The blinds the player misses meanwhile are owed.
+func (t *Table) SitOut(id string) error {
 	p, exists := t.players[id]
 	if !exists {
 		return ErrPlayerNotFound{id: id}
 	}
-	p.StopWatch()
-	waiting := slices.ContainsFunc(t.waiting, func(idd string) bool {
-		return idd == id
-	})
-	if !waiting {
-		t.left[id] = struct{}{}
+	if err := p.SitOut(); err != nil {
+		return fmt.Errorf("player (id: %s) sit out, err: %w", id, err)
+	}
+	return t.broadcast(player.EventSitOut, id)
+}
+
+// SitIn brings the player back to the next hands. A player owing blinds waits for
+// the big blind, unless choosing to post them or auto posting blinds.
+func (t *Table) SitIn(id string) error {
+	p, exists := t.players[id]
+	if !exists {
+		return ErrPlayerNotFound{id: id}
+	}
+	if err := p.SitIn(); err != nil {
+		return fmt.Errorf("player (id: %s) sit in, err: %w", id, err)
+	}
+	delete(t.away, id)
+	return t.broadcast(player.EventSitIn, id)
+}
+
+func (t *Table) broadcast(action player.EventAction, id string) error {
+	event := player.NewEvent(action, player.EventObject{ID: id})
+	if err := t.broadcaster.Action(event); err != nil {
+		return fmt.Errorf("broadcast event: %s, err: %w", action, err)
+	}
+	return nil
+}
+
+// sitOutTimedOut sits out the players who have timed out repeatedly in the last hand.
+func (t *Table) sitOutTimedOut() error {
+	if t.autoFoldTimeouts <= 0 {
 		return nil
 	}
+	for id, p := range t.players {
+		if p.SittingOut() || p.Timeouts() < t.autoFoldTimeouts {
+			continue
+		}
+		if err := t.broadcast(player.EventAutoFold, id); err != nil {
+			return err
+		}
+		if err := t.SitOut(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeAway removes the players who have sat out for too many orbits.
+func (t *Table) removeAway(ctx context.Context) error {
+	if t.maxOrbitsAway <= 0 {
+		return nil
+	}
+	for id, orbits := range t.away {
+		if orbits < t.maxOrbitsAway {
+			continue
+		}
+		delete(t.away, id)
+		if err := t.Leave(ctx, id); err != nil {
+			return fmt.Errorf("remove player (id: %s), err: %w", id, err)
+		}
+		if err := t.broadcast(player.EventRemoved, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Table) Leave(ctx context.Context, id string) error {
+	if _, exists := t.players[id]; !exists {
+		return ErrPlayerNotFound{id: id}
+	}
+	if t.sitAndGo != nil && t.sitAndGo.started {
+		// the chips stay in play until the player is busted
+		return t.SitOut(id)
+	}
+	t.leave(id)
+	return nil
+}
+
+func (t *Table) leave(id string) {
+	t.players[id].StopWatch()
 	t.waiting = slices.DeleteFunc(t.waiting, func(wid string) bool {
 		return wid == id
 	})
-	return nil
+	// the player is released and the seat vacated once no hand is running
+	t.left[id] = struct{}{}
 }
 
 func (t *Table) Start(ctx context.Context) error {
-	t.logEvents(ctx)
-	players := make([]*player.Player, 0, len(t.waiting))
-	roundNumber := 0
-	button := 0
-	dealer_ := dealer.New()
-
+	if t.sitAndGo != nil {
+		if err := t.startSitAndGo(ctx); err != nil {
+			return fmt.Errorf("start sit & go, err: %w", err)
+		}
+	}
 	for {
-		readyPosition := make([]*string, len(t.position))
-		copy(readyPosition, t.position)
-		readyPosition = slices.DeleteFunc(readyPosition, func(id *string) bool {
-			if id == nil {
-				return false
-			}
-			p := t.players[*id]
-			if p.Status() == player.StatusReady {
-				return false
-			}
-			return true
-		})
-		readyPlayers := make([]*player.Player, len(readyPosition))
-		readyPlayerCount := 0
-		for i, id := range readyPosition {
-			if id == nil {
-				continue
-			}
-			readyPlayers[i] = t.players[*id]
-			readyPlayerCount++
+		err := t.PlayHand(ctx)
+		var notEnoughPlayers ErrNotEnoughPlayers
+		if errors.As(err, &notEnoughPlayers) {
+			break
+		}
+		if err != nil {
+			return err
 		}
+		time.Sleep(5 * time.Second)
+	}
+	if t.sitAndGo != nil {
+		return t.finishSitAndGo(ctx)
+	}
+	return nil
+}
 
-		if MinPlayerCount > readyPlayerCount || readyPlayerCount > MaxPlayerCount {
-			break
+// PlayHand plays a single hand at the table. It returns ErrNotEnoughPlayers if
+// there are not enough players ready to be dealt in.
+func (t *Table) PlayHand(ctx context.Context) error {
+	t.hand.Lock()
+	defer t.hand.Unlock()
+
+	// release the players who have left since the last hand
+	if err := t.clean(ctx); err != nil {
+		return err
+	}
+	if err := t.buyChips(ctx); err != nil {
+		return err
+	}
+	if err := t.changeSeats(); err != nil {
+		return err
+	}
+	if err := t.sitOutTimedOut(); err != nil {
+		return fmt.Errorf("sit out timed out players, err: %w", err)
+	}
+	players, missed, err := t.nextHand()
+	if err != nil {
+		return err
+	}
+	if err := t.removeAway(ctx); err != nil {
+		return fmt.Errorf("remove away players, err: %w", err)
+	}
+	if t.sitAndGo != nil {
+		if err := t.beforeSitAndGoHand(); err != nil {
+			return err
 		}
+	}
+	small := t.positions.small
+	if players[small] == nil {
+		small = -1
+	}
 
-		for i := range t.capacity {
-			idPtr := t.position[(button+i)%t.capacity]
-			if idPtr == nil || t.players[*idPtr].Status() != player.StatusReady {
-				continue
-			}
-			button = i
+	opts := []round.Option{
+		round.WithNumber(t.hands),
+		round.WithVariant(t.variant),
+		round.WithMinBet(t.minBet),
+		round.WithBlinds(t.smallBlind, t.bigBlind),
+		round.WithAnte(t.ante),
+		round.WithBigBlindAnte(t.bigBlindAnte),
+		round.WithButtonAnte(t.buttonAnte),
+		round.WithStraddle(t.straddle),
+		round.WithBlindSeats(small, t.positions.big),
+		round.WithMissedBlinds(missed),
+		round.WithClock(t.clock),
+		round.WithBroadcaster(t.broadcaster),
+		round.WithDealer(t.dealer),
+		round.WithRake(t.rakePolicy),
+		round.WithLedger(t.ledger),
+		round.WithRunouts(t.runouts),
+	}
+	if t.limit != nil {
+		opts = append(opts, round.WithLimit(*t.limit))
+	}
+	if t.insurance != nil {
+		opts = append(opts, round.WithInsurance(*t.insurance))
+	}
+	t.round = round.New(players, t.positions.button, opts...)
+
+	err = t.round.Start(ctx)
+	t.round.End()
+	if err != nil {
+		return fmt.Errorf("start round, err: %w", err)
+	}
+
+	t.stats.record(t.round)
+	if rake := t.round.Rake(); len(rake) > 0 {
+		t.rakes.Record(t.hands, rake)
+	}
+	t.hands++
+	t.replenishTimeBanks(t.hands)
+	if t.sitAndGo != nil {
+		if err := t.afterSitAndGoHand(); err != nil {
+			return err
 		}
+	}
+	return t.clean(ctx)
+}
 
-		t.round = round.New(
-			readyPlayers,
-			round.WithNumber(roundNumber),
-			round.WithMinBet(t.minBet),
-			round.WithButton(button%len(players)),
-			round.WithBroadcaster(t.broadcaster),
-			round.WithDealer(dealer_),
-		)
-
-		err := t.round.Start(ctx)
-		t.round.End()
-		if err != nil {
-			return fmt.Errorf("start round, err: %w", err)
+// replenishTimeBanks adds time to every player's time bank each configured number of hands.
+func (t *Table) replenishTimeBanks(hands int) {
+	if t.timeBank.Every <= 0 || hands%t.timeBank.Every != 0 {
+		return
+	}
+	for _, p := range t.players {
+		p.AddTimeBank(t.timeBank.Replenish, t.timeBank.Max)
+	}
+}
+
+// nextHand moves the button and the blinds forward, and returns the players dealt in
+// the next hand by seats, along with the missed blinds they have to post.
+func (t *Table) nextHand() ([]*player.Player, map[string]round.MissedBlinds, error) {
+	ready := t.ready()
+	big := t.positions.nextBigBlind(ready)
+
+	// players owing blinds are dealt in only if they post them, or the big blind reaches them
+	dealt := make([]bool, len(ready))
+	for i, ok := range ready {
+		if !ok {
+			continue
 		}
-		time.Sleep(5 * time.Second)
+		_, owing := t.missed[*t.position[i]]
+		_, posting := t.posting[*t.position[i]]
+		posting = posting || t.players[*t.position[i]].AutoPostBlinds()
+		dealt[i] = !owing || posting || i == big
+	}
+	positions, err := t.positions.next(big, dealt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("move button, err: %w", err)
+	}
+	t.markMissedBlinds(positions, ready, dealt)
 
-		button++
-		roundNumber++
-		t.clean()
+	players := make([]*player.Player, len(t.position))
+	missed := make(map[string]round.MissedBlinds)
+	for i, ok := range dealt {
+		if !ok {
+			continue
+		}
+		id := *t.position[i]
+		players[i] = t.players[id]
+		if m, owing := t.missed[id]; owing && i != positions.big {
+			missed[id] = m
+		}
+		delete(t.missed, id)
+		delete(t.posting, id)
 	}
-	return nil
+	t.positions = positions
+	return players, missed, nil
+}
+
+// ready reports the seats which have a player ready to be dealt in.
+func (t *Table) ready() []bool {
+	ready := make([]bool, len(t.position))
+	for i, id := range t.position {
+		if id == nil {
+			continue
+		}
+		if _, left := t.left[*id]; left {
+			continue
+		}
+		// busted players are dealt in again once they rebuy, sit & go players sitting
+		// out are dealt in until busted
+		p := t.players[*id]
+		sittingOut := t.sitAndGo != nil && p.Status() == player.StatusSittingOut
+		ready[i] = (p.Status() == player.StatusReady || sittingOut) && p.Chips() > 0
+	}
+	return ready
 }
 
-func (t *Table) clean() {
-	func() {
-		for id := range t.left {
-			p := t.players[id]
-			p.Gone()
-			delete(t.players, id)
-			defer delete(t.left, id)
+// NextBigBlind returns the player due the big blind in the next hand, the one
+// tournaments move first when balancing the tables.
+func (t *Table) NextBigBlind() (*player.Player, bool) {
+	seat := t.positions.nextBigBlind(t.ready())
+	if seat < 0 {
+		return nil, false
+	}
+	return t.players[*t.position[seat]], true
+}
+
+// markMissedBlinds records the blinds missed by the seated players who are not dealt in.
+func (t *Table) markMissedBlinds(next blindPositions, ready, dealt []bool) {
+	if t.positions.big < 0 {
+		return
+	}
+	// the big blind has skipped these seats, so will the small blind
+	for _, seat := range passedSeats(t.positions.big, next.big, len(t.position)) {
+		id := t.position[seat]
+		if id == nil || ready[seat] {
+			continue
 		}
-	}()
+		t.missed[*id] = round.MissedBlinds{Small: true, Big: true}
+		if t.players[*id].SittingOut() {
+			// an orbit has gone since the big blind passed the seat last time
+			t.away[*id]++
+		}
+	}
+	if id := t.position[next.small]; id != nil && !dealt[next.small] {
+		missed := t.missed[*id]
+		missed.Small = true
+		t.missed[*id] = missed
+	}
 }
 
-func (t *Table) logEvents(ctx context.Context) {
-	go func() {
-		select {
-		case <-ctx.Done():
-			return
-		case event := <-t.watcher.Watch():
-			klog.V(3).Info(event)
+// Close releases the players who have left and stops the events of the table, once
+// no one plays at it anymore, such as a tournament table broken up.
+func (t *Table) Close(ctx context.Context) error {
+	t.hand.Lock()
+	defer t.hand.Unlock()
+	if err := t.clean(ctx); err != nil {
+		return err
+	}
+	t.broadcaster.Shutdown()
+	return nil
+}
+
+// clean releases the players who have left, cashing out their chips. A player whose
+// chips fail to be cashed out is kept until the next time.
+func (t *Table) clean(ctx context.Context) error {
+	// sit & go chips are not money, the prizes are paid instead
+	wallet := t.wallet
+	if t.sitAndGo != nil {
+		wallet = nil
+	}
+	for id := range t.left {
+		p := t.players[id]
+		if p.Chips() > 0 {
+			if _, err := t.ledger.CashOut(ctx, wallet, id, p.Chips()); err != nil {
+				return fmt.Errorf("cash out player (id: %s), err: %w", id, err)
+			}
 		}
-	}()
+		p.Gone()
+		delete(t.left, id)
+		delete(t.players, id)
+		delete(t.missed, id)
+		delete(t.posting, id)
+		delete(t.away, id)
+		delete(t.buying, id)
+		// vacate the seat, the button or small blind might be dead there
+		for i, idPtr := range t.position {
+			if idPtr == nil || *idPtr != id {
+				continue
+			}
+			t.position[i] = nil
+			if err := t.announce(EventSeatOpen, EventObject{ID: id, Seat: i}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// logEvents logs the events of the table until the watcher stops, the hands never
+// wait on it, whether played by Start or one at a time by PlayHand.
+func (t *Table) logEvents() {
+	for event := range t.watcher.Watch() {
+		klog.V(3).Info(event)
+	}
 }
@@ -1,6 +1,155 @@
 package table
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yshngg/holdem/pkg/dealer"
+	"github.com/yshngg/holdem/pkg/ledger"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/round"
+	"github.com/yshngg/holdem/pkg/variant"
+	"github.com/yshngg/holdem/pkg/watch"
+)
 
 func TestTable(t *testing.T) {
 }
+
+// watchEvents collects the events the player watches, until the player stops watching.
+func watchEvents(p *player.Player) <-chan []watch.Event {
+	out := make(chan []watch.Event, 1)
+	go func() {
+		events := make([]watch.Event, 0)
+		for event := range p.Watch() {
+			events = append(events, event)
+		}
+		out <- events
+	}()
+	return out
+}
+
+// join seats the players at the table, ready to be dealt in.
+func join(t *testing.T, table *Table, chips int, ids ...string) []*player.Player {
+	t.Helper()
+	players := make([]*player.Player, 0, len(ids))
+	for _, id := range ids {
+		p, err := table.Join(t.Context(), id, id, chips)
+		if err != nil {
+			t.Fatalf("join table, err: %v", err)
+		}
+		if err := p.Ready(); err != nil {
+			t.Fatalf("player ready, err: %v", err)
+		}
+		players = append(players, p)
+	}
+	return players
+}
+
+func TestCardsHidden(t *testing.T) {
+	testCases := []struct {
+		name    string
+		variant variant.Variant
+		action  dealer.EventAction
+		want    int
+	}{
+		{"Draw", variant.TripleDraw27, dealer.EventDrawCards, 3},
+		{"Discard", variant.Pineapple, dealer.EventMuckCards, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// everyone checks or calls down, drawing the first card every time and
+			// discarding the suggested cards
+			policy := func(available []player.Action) (player.Action, error) {
+				for _, action := range available {
+					switch action.Type {
+					case player.ActionDraw:
+						action.Discard = []int{0}
+						return action, nil
+					case player.ActionDiscard, player.ActionCheck, player.ActionCall:
+						return action, nil
+					}
+				}
+				return player.CheckOrFold(available)
+			}
+			table := New(
+				WithVariant(tc.variant),
+				WithCapacity(2),
+				WithActionClock(round.Clock{Base: time.Millisecond}),
+				WithTimeoutPolicy(policy),
+			)
+			players := join(t, table, 100, "alice", "bob")
+			alice, bob := players[0], players[1]
+			events := watchEvents(alice)
+			watchEvents(bob)
+
+			if err := table.PlayHand(t.Context()); err != nil {
+				t.Fatalf("play hand, err: %v", err)
+			}
+			alice.StopWatch()
+			bob.StopWatch()
+
+			seen := map[string]int{}
+			for _, event := range <-events {
+				if event.Kind() != dealer.EventKind || event.Action() != tc.action {
+					continue
+				}
+				object := event.Related().(dealer.EventObject)
+				for _, c := range object.Cards {
+					if c != nil {
+						seen[object.To]++
+					}
+				}
+				if object.To == dealer.ToPlayer(bob) && len(object.Cards) == 0 {
+					t.Errorf("%s of bob has no cards, want as many hidden cards", tc.action)
+				}
+			}
+			if seen[dealer.ToPlayer(alice)] != tc.want {
+				t.Errorf("cards of alice seen: %d, want: %d", seen[dealer.ToPlayer(alice)], tc.want)
+			}
+			if seen[dealer.ToPlayer(bob)] != 0 {
+				t.Errorf("cards of bob seen: %d, want: %d", seen[dealer.ToPlayer(bob)], 0)
+			}
+		})
+	}
+}
+
+// failingWallet debits the buy-ins, but fails to credit the cash-outs.
+type failingWallet struct {
+	ledger.Wallet
+}
+
+func (w failingWallet) Credit(ctx context.Context, id string, amount int) error {
+	return errors.New("wallet unavailable")
+}
+
+func TestWallet(t *testing.T) {
+	wallet := failingWallet{ledger.NewMemoryWallet(map[string]int{"alice": 100, "bob": 100, "carol": 100})}
+	table := New(WithCapacity(3), WithWallet(wallet), WithActionClock(round.Clock{Base: time.Millisecond}))
+	for _, p := range join(t, table, 100, "alice", "bob") {
+		watchEvents(p)
+	}
+
+	// the buy-in is debited with the caller's context
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := table.Join(ctx, "carol", "carol", 100); !errors.Is(err, context.Canceled) {
+		t.Errorf("join with the context canceled, err: %v, want: %v", err, context.Canceled)
+	}
+
+	// the chips of alice stay at the table until cashed out
+	if err := table.Leave(t.Context(), "alice"); err != nil {
+		t.Fatalf("leave, err: %v", err)
+	}
+	if err := table.PlayHand(t.Context()); err == nil {
+		t.Errorf("play hand, want the cash-out of alice failed")
+	}
+	if got := table.Ledger().Balance(ledger.PlayerAccount("alice")); got != 100 {
+		t.Errorf("balance of alice: %d, want: %d", got, 100)
+	}
+	if _, err := table.Join(t.Context(), "carol", "carol", 100); err == nil {
+		t.Errorf("join, want the seat of alice kept until cashed out")
+	}
+}
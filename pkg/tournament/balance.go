@@ -0,0 +1,76 @@
+package tournament
+
+// move is a player moved between two tables, by their indexes.
+type move struct {
+	from, to int
+}
+
+// balance plans how to balance the tables, given the player count of each table
+// and the seating capacity. It breaks the tables no longer needed, moving their
+// players to the tables with the fewest players, then moves players from the
+// largest to the smallest tables until they differ by at most one player.
+func balance(counts []int, capacity int) (broken []int, moves []move) {
+	counts = append([]int(nil), counts...)
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	needed := (total + capacity - 1) / capacity
+	if needed < 1 {
+		needed = 1
+	}
+
+	open := make([]bool, len(counts))
+	for i := range open {
+		open[i] = true
+	}
+	for len(counts)-len(broken) > needed {
+		// break the table with the fewest players
+		smallest := -1
+		for i, count := range counts {
+			if open[i] && (smallest < 0 || count <= counts[smallest]) {
+				smallest = i
+			}
+		}
+		open[smallest] = false
+		broken = append(broken, smallest)
+		for ; counts[smallest] > 0; counts[smallest]-- {
+			to := fewest(counts, open)
+			counts[to]++
+			moves = append(moves, move{from: smallest, to: to})
+		}
+	}
+
+	for {
+		from, to := most(counts, open), fewest(counts, open)
+		if counts[from]-counts[to] <= 1 {
+			break
+		}
+		counts[from]--
+		counts[to]++
+		moves = append(moves, move{from: from, to: to})
+	}
+	return broken, moves
+}
+
+// fewest returns the open table with the fewest players.
+func fewest(counts []int, open []bool) int {
+	index := -1
+	for i, count := range counts {
+		if open[i] && (index < 0 || count < counts[index]) {
+			index = i
+		}
+	}
+	return index
+}
+
+// most returns the open table with the most players.
+func most(counts []int, open []bool) int {
+	index := -1
+	for i, count := range counts {
+		if open[i] && (index < 0 || count > counts[index]) {
+			index = i
+		}
+	}
+	return index
+}
@@ -0,0 +1,153 @@
+package tournament
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/yshngg/holdem/pkg/payout"
+	"github.com/yshngg/holdem/pkg/round"
+	"github.com/yshngg/holdem/pkg/table"
+)
+
+func TestBalance(t *testing.T) {
+	testCases := []struct {
+		name     string
+		counts   []int
+		capacity int
+		broken   []int
+		want     []int
+	}{
+		{
+			name:     "Balanced",
+			counts:   []int{9, 8, 8},
+			capacity: 9,
+			want:     []int{9, 8, 8},
+		},
+		{
+			name:     "MoveOne",
+			counts:   []int{9, 7, 8},
+			capacity: 9,
+			want:     []int{8, 8, 8},
+		},
+		{
+			name:     "MoveMany",
+			counts:   []int{9, 4, 9},
+			capacity: 9,
+			want:     []int{7, 7, 8},
+		},
+		{
+			name:     "BreakTable",
+			counts:   []int{6, 5, 6},
+			capacity: 9,
+			broken:   []int{1},
+			want:     []int{9, 0, 8},
+		},
+		{
+			name:     "FinalTable",
+			counts:   []int{3, 2},
+			capacity: 9,
+			broken:   []int{1},
+			want:     []int{5, 0},
+		},
+		{
+			name:     "BreakTwoTables",
+			counts:   []int{4, 2, 3, 2},
+			capacity: 6,
+			broken:   []int{3, 2},
+			want:     []int{6, 5, 0, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			broken, moves := balance(tc.counts, tc.capacity)
+			if !slices.Equal(broken, tc.broken) {
+				t.Errorf("broken: %v, want: %v", broken, tc.broken)
+			}
+			got := slices.Clone(tc.counts)
+			for _, m := range moves {
+				got[m.from]--
+				got[m.to]++
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("counts: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFinishingPlaces(t *testing.T) {
+	testCases := []struct {
+		name      string
+		busted    []string
+		stacks    map[string]int
+		remaining int
+		want      map[string]int
+	}{
+		{
+			name:      "One",
+			busted:    []string{"a"},
+			stacks:    map[string]int{"a": 100},
+			remaining: 10,
+			want:      map[string]int{"a": 10},
+		},
+		{
+			name:      "BiggerStackPlacesBetter",
+			busted:    []string{"a", "b"},
+			stacks:    map[string]int{"a": 100, "b": 300},
+			remaining: 10,
+			want:      map[string]int{"a": 10, "b": 9},
+		},
+		{
+			name:      "Tie",
+			busted:    []string{"a", "b", "c"},
+			stacks:    map[string]int{"a": 100, "b": 300, "c": 100},
+			remaining: 3,
+			want:      map[string]int{"a": 2, "b": 1, "c": 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := payout.Places(tc.busted, tc.stacks, tc.remaining)
+			for id, place := range tc.want {
+				if got[id] != place {
+					t.Errorf("place of %s: %d, want: %d", id, got[id], place)
+				}
+			}
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+	testCases := []struct {
+		name  string
+		hands int
+		want  string
+	}{
+		// the button starts from the first seat
+		{name: "FirstHand", want: "p2"},
+		{name: "AfterHand", hands: 1, want: "p3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := New(WithTableOptions(table.WithActionClock(round.Clock{Base: time.Millisecond})))
+			seated(t, tr, "p0", "p1", "p2", "p3", "p4")
+			for range tc.hands {
+				if _, err := tr.playHands(t.Context()); err != nil {
+					t.Fatalf("play hands, err: %v", err)
+				}
+			}
+
+			from, to := tr.tables[0], &seatedTable{number: 1, table: table.New(table.WithNewPlayersDealtIn())}
+			if err := tr.move(t.Context(), from, to); err != nil {
+				t.Fatalf("move, err: %v", err)
+			}
+			if seated := tr.seats[tc.want]; seated != to {
+				t.Errorf("%s seated at table %d, want the player due the big blind moved", tc.want, seated.number)
+			}
+		})
+	}
+}
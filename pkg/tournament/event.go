@@ -0,0 +1,78 @@
+package tournament
+
+import (
+	"time"
+
+	"github.com/yshngg/holdem/pkg/watch"
+)
+
+type EventAction string
+
+const (
+	EventKind string = "tournament"
+
+	EventRegister   EventAction = "Register"
+	EventStart      EventAction = "Start"
+	EventLevelUp    EventAction = "LevelUp"
+	EventEliminate  EventAction = "Eliminate"
+	EventMove       EventAction = "Move"
+	EventBreakTable EventAction = "BreakTable"
+	EventFinalTable EventAction = "FinalTable"
+	EventFinish     EventAction = "Finish"
+	EventPayout     EventAction = "Payout"
+	EventRebuy      EventAction = "Rebuy"
+	EventAddOn      EventAction = "AddOn"
+	EventReEntry    EventAction = "ReEntry"
+)
+
+type EventObject struct {
+	// ID is the player the event is about, if any.
+	ID string
+
+	// Table is the table the event is about, the destination table of a move.
+	Table int
+
+	// Level is the index of the current blind level.
+	Level int
+
+	// Place is the finishing position of an eliminated player or the winner.
+	Place int
+
+	// Prize is the amount paid to the player.
+	Prize int
+
+	// Chips is the chips bought by the player.
+	Chips int
+}
+
+type Event struct {
+	action    EventAction
+	object    EventObject
+	eventTime time.Time
+}
+
+func NewEvent(action EventAction, object EventObject) watch.Event {
+	return Event{
+		action:    action,
+		object:    object,
+		eventTime: time.Now(),
+	}
+}
+
+func (e Event) Kind() string {
+	return EventKind
+}
+
+func (e Event) Action() string {
+	return string(e.action)
+}
+
+func (e Event) Related() any {
+	return e.object
+}
+
+func (e Event) Time() time.Time {
+	return e.eventTime
+}
+
+var _ watch.Event = Event{}
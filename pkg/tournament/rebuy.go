@@ -0,0 +1,238 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/ledger"
+)
+
+// Rebuy configures the rebuy period, during which players with at most the
+// starting stack can buy chips.
+type Rebuy struct {
+	// Levels is how many levels the rebuy period lasts, zero disables rebuys.
+	Levels int
+
+	// Max is the most rebuys a player can make, zero means no limit.
+	Max int
+
+	// Chips are bought with every rebuy, they default to the starting stack.
+	Chips int
+	Cost  int
+}
+
+// AddOn configures the add-on every player can take once, usually at the end of
+// the rebuy period.
+type AddOn struct {
+	// Level is the index of the level the add-on is offered during.
+	Level int
+
+	// Chips are bought with the add-on, zero disables add-ons.
+	Chips int
+	Cost  int
+}
+
+// ReEntry configures the late registration period, during which eliminated players
+// can enter again with a new starting stack.
+type ReEntry struct {
+	// Levels is how many levels the re-entry period lasts, zero disables re-entries.
+	Levels int
+
+	// Max is the most re-entries a player can make, zero means no limit.
+	Max int
+}
+
+func WithRebuy(rebuy Rebuy) Option {
+	return func(t *Tournament) {
+		t.rebuy = rebuy
+	}
+}
+
+func WithAddOn(addOn AddOn) Option {
+	return func(t *Tournament) {
+		t.addOn = addOn
+	}
+}
+
+// WithReEntry enables re-entries, which cost the buy-in.
+func WithReEntry(reEntry ReEntry) Option {
+	return func(t *Tournament) {
+		t.reEntry = reEntry
+	}
+}
+
+type ErrNotRegistered struct {
+	id string
+}
+
+func (e ErrNotRegistered) Error() string {
+	return fmt.Sprintf("player (id: %s) has not registered", e.id)
+}
+
+type ErrPurchaseClosed struct {
+	kind ledger.Kind
+}
+
+func (e ErrPurchaseClosed) Error() string {
+	return fmt.Sprintf("%s is not available", e.kind)
+}
+
+type ErrPurchaseLimit struct {
+	kind ledger.Kind
+	max  int
+}
+
+func (e ErrPurchaseLimit) Error() string {
+	return fmt.Sprintf("%s limit reached: %d", e.kind, e.max)
+}
+
+// Rebuy buys the rebuy chips for the player once the hand ends. A player busted
+// in the hand with a rebuy requested stays in the tournament.
+func (t *Tournament) Rebuy(ctx context.Context, id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.seats[id]; !ok {
+		return ErrNotRegistered{id: id}
+	}
+	if !t.started || t.level() >= t.rebuy.Levels {
+		return ErrPurchaseClosed{kind: ledger.Rebuy}
+	}
+	if t.rebuy.Max > 0 && t.purchases.Count(id, ledger.Rebuy)+t.buying[id][ledger.Rebuy] >= t.rebuy.Max {
+		return ErrPurchaseLimit{kind: ledger.Rebuy, max: t.rebuy.Max}
+	}
+	if err := t.pay(ctx, id, t.rebuy.Cost); err != nil {
+		return err
+	}
+	t.buy(id, ledger.Rebuy)
+	return nil
+}
+
+// AddOn buys the add-on chips for the player once the hand ends.
+func (t *Tournament) AddOn(ctx context.Context, id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.seats[id]; !ok {
+		return ErrNotRegistered{id: id}
+	}
+	if !t.started || t.addOn.Chips <= 0 || t.level() != t.addOn.Level {
+		return ErrPurchaseClosed{kind: ledger.AddOn}
+	}
+	if t.purchases.Count(id, ledger.AddOn)+t.buying[id][ledger.AddOn] >= 1 {
+		return ErrPurchaseLimit{kind: ledger.AddOn, max: 1}
+	}
+	if err := t.pay(ctx, id, t.addOn.Cost); err != nil {
+		return err
+	}
+	t.buy(id, ledger.AddOn)
+	return nil
+}
+
+// ReEnter enters an eliminated player again, seated once the hand ends.
+func (t *Tournament) ReEnter(ctx context.Context, id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !slices.ContainsFunc(t.entrants, func(e Entrant) bool { return e.ID == id }) {
+		return ErrNotRegistered{id: id}
+	}
+	if _, ok := t.seats[id]; ok || !t.started || t.level() >= t.reEntry.Levels {
+		return ErrPurchaseClosed{kind: ledger.ReEntry}
+	}
+	if t.reEntry.Max > 0 && t.purchases.Count(id, ledger.ReEntry)+t.buying[id][ledger.ReEntry] >= t.reEntry.Max {
+		return ErrPurchaseLimit{kind: ledger.ReEntry, max: t.reEntry.Max}
+	}
+	if err := t.pay(ctx, id, t.buyIn); err != nil {
+		return err
+	}
+	t.buy(id, ledger.ReEntry)
+	return nil
+}
+
+// Purchases returns every chip purchase made in the tournament, in order.
+func (t *Tournament) Purchases() []ledger.Purchase {
+	return t.purchases.Records("")
+}
+
+func (t *Tournament) buy(id string, kind ledger.Kind) {
+	if t.buying[id] == nil {
+		t.buying[id] = make(map[ledger.Kind]int)
+	}
+	t.buying[id][kind]++
+}
+
+func (t *Tournament) level() int {
+	index, _ := t.progress.Level()
+	return index
+}
+
+// buyChips adds the rebuys and add-ons bought during the last hands, before the busted
+// players are eliminated.
+func (t *Tournament) buyChips(ctx context.Context) error {
+	for id, kinds := range t.buying {
+		p, ok := t.player(id)
+		if !ok {
+			continue
+		}
+		seated := t.seats[id]
+		for range kinds[ledger.Rebuy] {
+			if p.Chips() > t.startingStack {
+				// the stack has grown beyond the rebuy limit during the hand
+				if err := t.refund(ctx, id, t.rebuy.Cost); err != nil {
+					return err
+				}
+				continue
+			}
+			chips := t.rebuy.Chips
+			if chips <= 0 {
+				chips = t.startingStack
+			}
+			if err := seated.table.AddChips(ctx, id, ledger.Rebuy, chips); err != nil {
+				return fmt.Errorf("rebuy player (id: %s), err: %w", id, err)
+			}
+			t.purchases.Record(id, ledger.Rebuy, chips, t.rebuy.Cost)
+			if err := t.broadcast(EventRebuy, EventObject{ID: id, Chips: chips}); err != nil {
+				return err
+			}
+		}
+		if kinds[ledger.AddOn] > 0 {
+			if err := seated.table.AddChips(ctx, id, ledger.AddOn, t.addOn.Chips); err != nil {
+				return fmt.Errorf("add on player (id: %s), err: %w", id, err)
+			}
+			t.purchases.Record(id, ledger.AddOn, t.addOn.Chips, t.addOn.Cost)
+			if err := t.broadcast(EventAddOn, EventObject{ID: id, Chips: t.addOn.Chips}); err != nil {
+				return err
+			}
+		}
+		delete(kinds, ledger.Rebuy)
+		delete(kinds, ledger.AddOn)
+	}
+	return nil
+}
+
+// reEnter seats the players re-entering at the tables with the fewest players,
+// replacing the places they were eliminated in.
+func (t *Tournament) reEnter(ctx context.Context) error {
+	for id, kinds := range t.buying {
+		if kinds[ledger.ReEntry] == 0 {
+			continue
+		}
+		delete(t.buying, id)
+		seated := t.tables[0]
+		for _, other := range t.tables {
+			if other.table.PlayerCount() < seated.table.PlayerCount() {
+				seated = other
+			}
+		}
+		if err := t.join(ctx, seated, id, t.startingStack); err != nil {
+			return err
+		}
+		t.standings = slices.DeleteFunc(t.standings, func(standing Standing) bool {
+			return standing.ID == id
+		})
+		t.purchases.Record(id, ledger.ReEntry, t.startingStack, t.buyIn)
+		if err := t.broadcast(EventReEntry, EventObject{ID: id, Table: seated.number}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yshngg/holdem/pkg/round"
+	"github.com/yshngg/holdem/pkg/table"
+)
+
+func TestRebuy(t *testing.T) {
+	tr := New(
+		WithStartingStack(100),
+		WithRebuy(Rebuy{Levels: 1}),
+		WithTableOptions(table.WithActionClock(round.Clock{Base: time.Millisecond}), table.WithTimeoutPolicy(allIn)),
+	)
+	seated(t, tr, "a", "b")
+	for _, id := range []string{"a", "b"} {
+		if err := tr.Rebuy(t.Context(), id); err != nil {
+			t.Fatalf("rebuy, err: %v", err)
+		}
+	}
+	if err := tr.buyChips(t.Context()); err != nil {
+		t.Fatalf("buy chips, err: %v", err)
+	}
+	for _, id := range []string{"a", "b"} {
+		if p, _ := tr.Player(id); p.Chips() != 200 {
+			t.Fatalf("chips of %s: %d, want the starting stack rebought", id, p.Chips())
+		}
+	}
+
+	// both bet all their chips, the rebought ones too
+	if err := tr.tables[0].table.PlayHand(t.Context()); err != nil {
+		t.Fatalf("play hands, err: %v", err)
+	}
+	l := tr.tables[0].table.Ledger()
+	if !l.Balanced() {
+		t.Errorf("ledger is not balanced: %v", l.Transactions())
+	}
+	total := 0
+	for _, p := range tr.tables[0].table.Players() {
+		total += p.Chips()
+	}
+	if total != 400 {
+		t.Errorf("chips at the table: %d, want the stacks and the rebuys", total)
+	}
+}
@@ -0,0 +1,571 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yshngg/holdem/pkg/blind"
+	"github.com/yshngg/holdem/pkg/ledger"
+	"github.com/yshngg/holdem/pkg/payout"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/table"
+	"github.com/yshngg/holdem/pkg/watch"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultTableCapacity = 9
+	defaultStartingStack = 10000
+)
+
+// Tournament is a multi-table tournament. Every table plays a hand at the same time,
+// eliminations, blind levels and table balancing are handled between the hands.
+type Tournament struct {
+	mu sync.Mutex
+
+	// tableCapacity is the seating capacity of every table.
+	tableCapacity int
+
+	// startingStack is the chips every entrant starts with.
+	startingStack int
+
+	// buyIn is what every entry costs.
+	buyIn int
+
+	rebuy   Rebuy
+	addOn   AddOn
+	reEntry ReEntry
+
+	// buying counts the purchases requested by every player during the hands,
+	// made once they end.
+	buying map[string]map[ledger.Kind]int
+
+	// purchases records every chip purchase.
+	purchases *ledger.Purchases
+
+	// wallet is the players' bankroll, debited by the purchases and credited by the prizes.
+	wallet ledger.Wallet
+
+	// prizePool is paid out by the payout structure once the tournament finishes.
+	prizePool int
+	payouts   payout.Structure
+
+	schedule blind.Schedule
+	progress *blind.Progress
+
+	// tableOptions are applied to every table.
+	tableOptions []table.Option
+
+	// entrants are the registered players in registration order.
+	entrants []Entrant
+
+	started bool
+
+	// tables are the tables in play, tables which have been broken are removed.
+	tables []*seatedTable
+
+	// seats indicates the table every remaining player sits at.
+	seats map[string]*seatedTable
+
+	// standings are the finishing positions, from the last place.
+	standings []Standing
+
+	broadcaster watch.Broadcaster
+}
+
+// seatedTable is a table in play along with its number in the tournament.
+type seatedTable struct {
+	number int
+	table  *table.Table
+}
+
+type Entrant struct {
+	Name string
+	ID   string
+}
+
+// Standing is the finishing position of a player.
+type Standing struct {
+	Entrant
+
+	// Place is the finishing position, 1 for the winner. Players busted in the same
+	// hand starting it with the same stack share a place.
+	Place int
+
+	// Prize is paid once the tournament finishes.
+	Prize int
+}
+
+func New(opts ...Option) *Tournament {
+	t := &Tournament{}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.tableCapacity < table.MinPlayerCount || t.tableCapacity > table.MaxPlayerCount {
+		t.tableCapacity = defaultTableCapacity
+	}
+	if t.startingStack <= 0 {
+		t.startingStack = defaultStartingStack
+	}
+	t.seats = make(map[string]*seatedTable)
+	t.buying = make(map[string]map[ledger.Kind]int)
+	t.purchases = ledger.NewPurchases()
+	t.broadcaster = watch.NewBroadcaster(t.tableCapacity*2, t.tableCapacity*2)
+	return t
+}
+
+type Option func(t *Tournament)
+
+func WithTableCapacity(capacity int) Option {
+	return func(t *Tournament) {
+		t.tableCapacity = capacity
+	}
+}
+
+func WithStartingStack(chips int) Option {
+	return func(t *Tournament) {
+		t.startingStack = chips
+	}
+}
+
+func WithBuyIn(cost int) Option {
+	return func(t *Tournament) {
+		t.buyIn = cost
+	}
+}
+
+func WithWallet(wallet ledger.Wallet) Option {
+	return func(t *Tournament) {
+		t.wallet = wallet
+	}
+}
+
+func WithPrizePool(pool int, payouts payout.Structure) Option {
+	return func(t *Tournament) {
+		t.prizePool = pool
+		t.payouts = payouts
+	}
+}
+
+// WithSchedule sets the blind levels, lasting for a time or a number of hands.
+func WithSchedule(schedule blind.Schedule) Option {
+	return func(t *Tournament) {
+		t.schedule = schedule
+	}
+}
+
+// WithTableOptions sets the options applied to every table, such as clocks and time banks.
+func WithTableOptions(opts ...table.Option) Option {
+	return func(t *Tournament) {
+		t.tableOptions = append(t.tableOptions, opts...)
+	}
+}
+
+type ErrAlreadyRegistered struct {
+	id string
+}
+
+func (e ErrAlreadyRegistered) Error() string {
+	return fmt.Sprintf("player (id: %s) has already registered", e.id)
+}
+
+type ErrRegistrationClosed struct{}
+
+func (e ErrRegistrationClosed) Error() string {
+	return "registration is closed"
+}
+
+type ErrNotEnoughEntrants struct {
+	count int
+}
+
+func (e ErrNotEnoughEntrants) Error() string {
+	return fmt.Sprintf("not enough entrants to start the tournament: %d", e.count)
+}
+
+// ErrNoHandDealt means no table can deal a hand while several players are left, such
+// as when they are sitting out.
+type ErrNoHandDealt struct {
+	count int
+}
+
+func (e ErrNoHandDealt) Error() string {
+	return fmt.Sprintf("no hand can be dealt, players left: %d", e.count)
+}
+
+func (t *Tournament) Register(ctx context.Context, name, id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.started {
+		return ErrRegistrationClosed{}
+	}
+	if slices.ContainsFunc(t.entrants, func(e Entrant) bool { return e.ID == id }) {
+		return ErrAlreadyRegistered{id: id}
+	}
+	if err := t.pay(ctx, id, t.buyIn); err != nil {
+		return err
+	}
+	t.entrants = append(t.entrants, Entrant{Name: name, ID: id})
+	t.purchases.Record(id, ledger.BuyIn, t.startingStack, t.buyIn)
+	return t.broadcast(EventRegister, EventObject{ID: id})
+}
+
+func (t *Tournament) Watch() (watch.Interface, error) {
+	return t.broadcaster.Watch()
+}
+
+// Player returns the player of an entrant at the table currently seating it.
+// Moving to another table seats a new player, so it has to be looked up again.
+func (t *Tournament) Player(id string) (*player.Player, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.player(id)
+}
+
+func (t *Tournament) player(id string) (*player.Player, bool) {
+	seated, ok := t.seats[id]
+	if !ok {
+		return nil, false
+	}
+	for _, p := range seated.table.Players() {
+		if p.ID() == id {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Standings returns the finishing positions so far, from the first place.
+func (t *Tournament) Standings() []Standing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	standings := slices.Clone(t.standings)
+	slices.Reverse(standings)
+	return standings
+}
+
+// Start seats the entrants and plays until a single player holds all the chips.
+func (t *Tournament) Start(ctx context.Context) error {
+	t.mu.Lock()
+	if len(t.entrants) < table.MinPlayerCount {
+		t.mu.Unlock()
+		return ErrNotEnoughEntrants{count: len(t.entrants)}
+	}
+	t.started = true
+	t.progress = blind.NewProgress(t.schedule, time.Now())
+	err := t.seat(ctx)
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := t.broadcast(EventStart, EventObject{}); err != nil {
+		return err
+	}
+
+	for len(t.seats) > 1 {
+		stacks := t.stacks()
+		played, err := t.playHands(ctx)
+		if err != nil {
+			return err
+		}
+		if played == 0 {
+			// no player would ever bust, nor the tables change
+			return ErrNoHandDealt{count: len(t.seats)}
+		}
+		t.mu.Lock()
+		err = t.afterHands(ctx, stacks)
+		t.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	var winner string
+	for id := range t.seats {
+		winner = id
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.standings = append(t.standings, Standing{Entrant: t.entrant(winner), Place: 1})
+	if err := t.broadcast(EventFinish, EventObject{ID: winner, Place: 1}); err != nil {
+		return err
+	}
+	return t.payOut(ctx)
+}
+
+// payOut pays the prizes by the finishing places.
+func (t *Tournament) payOut(ctx context.Context) error {
+	places := make([]int, len(t.standings))
+	for i, standing := range t.standings {
+		places[i] = standing.Place
+	}
+	prizes := t.payouts.Prizes(t.prizePool, places)
+	for i := len(t.standings) - 1; i >= 0; i-- {
+		standing := &t.standings[i]
+		standing.Prize = prizes[i]
+		if standing.Prize == 0 {
+			continue
+		}
+		if t.wallet != nil {
+			if err := t.wallet.Credit(ctx, standing.ID, standing.Prize); err != nil {
+				return fmt.Errorf("pay prize to player (id: %s), err: %w", standing.ID, err)
+			}
+		}
+		object := EventObject{ID: standing.ID, Place: standing.Place, Prize: standing.Prize}
+		if err := t.broadcast(EventPayout, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Equities returns the expected prizes of the remaining players by the ICM,
+// to make deals on.
+func (t *Tournament) Equities() map[string]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ids := make([]string, 0, len(t.seats))
+	stacks := make([]int, 0, len(t.seats))
+	for id, chips := range t.stacks() {
+		ids = append(ids, id)
+		stacks = append(stacks, chips)
+	}
+	// the remaining players compete for the best places
+	prizes := t.payouts.Amounts(t.prizePool)
+	prizes = prizes[:min(len(prizes), len(stacks))]
+
+	equities := make(map[string]float64, len(ids))
+	for i, equity := range payout.ICM(stacks, prizes) {
+		equities[ids[i]] = equity
+	}
+	return equities
+}
+
+// seat spreads the entrants over as few tables as needed, in registration order.
+func (t *Tournament) seat(ctx context.Context) error {
+	count := (len(t.entrants) + t.tableCapacity - 1) / t.tableCapacity
+	_, level := t.progress.Level()
+	for i := range count {
+		opts := append(slices.Clone(t.tableOptions),
+			table.WithCapacity(t.tableCapacity),
+			table.WithNewPlayersDealtIn(),
+			// moved players bring any stack
+			table.WithChipsThreshold(1),
+		)
+		tb := table.New(opts...)
+		tb.SetLevel(level)
+		t.tables = append(t.tables, &seatedTable{number: i, table: tb})
+	}
+	for i, entrant := range t.entrants {
+		if err := t.join(ctx, t.tables[i%count], entrant.ID, t.startingStack); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Tournament) join(ctx context.Context, seated *seatedTable, id string, chips int) error {
+	entrant := t.entrant(id)
+	p, err := seated.table.Join(ctx, entrant.Name, id, chips)
+	if err != nil {
+		return fmt.Errorf("seat player (id: %s) at table %d, err: %w", id, seated.number, err)
+	}
+	if err := p.Ready(); err != nil {
+		return fmt.Errorf("ready player (id: %s), err: %w", id, err)
+	}
+	t.seats[id] = seated
+	return nil
+}
+
+// playHands plays a hand at every table at the same time, it returns the number of
+// tables which have dealt one.
+func (t *Tournament) playHands(ctx context.Context) (int, error) {
+	g, ctx := errgroup.WithContext(ctx)
+	var played atomic.Int32
+	for _, seated := range t.tables {
+		g.Go(func() error {
+			err := seated.table.PlayHand(ctx)
+			var notEnoughPlayers table.ErrNotEnoughPlayers
+			if errors.As(err, &notEnoughPlayers) {
+				// the table is balanced once the other tables have played
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("play hand at table %d, err: %w", seated.number, err)
+			}
+			played.Add(1)
+			return nil
+		})
+	}
+	err := g.Wait()
+	return int(played.Load()), err
+}
+
+// stacks returns the chips of every remaining player.
+func (t *Tournament) stacks() map[string]int {
+	stacks := make(map[string]int, len(t.seats))
+	for _, seated := range t.tables {
+		for _, p := range seated.table.Players() {
+			stacks[p.ID()] = p.Chips()
+		}
+	}
+	return stacks
+}
+
+// afterHands makes the purchases, eliminates the busted players, raises the blinds
+// and balances the tables.
+// stacks are the chips the players started the hands with.
+func (t *Tournament) afterHands(ctx context.Context, stacks map[string]int) error {
+	if err := t.buyChips(ctx); err != nil {
+		return err
+	}
+	if err := t.eliminate(ctx, stacks); err != nil {
+		return err
+	}
+	if err := t.reEnter(ctx); err != nil {
+		return err
+	}
+	if len(t.seats) <= 1 {
+		return nil
+	}
+
+	before, _ := t.progress.Level()
+	index, level := t.progress.Advance(time.Now(), 1)
+	if index != before {
+		for _, seated := range t.tables {
+			seated.table.SetLevel(level)
+		}
+		if err := t.broadcast(EventLevelUp, EventObject{Level: index}); err != nil {
+			return err
+		}
+	}
+	return t.balance(ctx)
+}
+
+// eliminate removes the players who have no chips left, and records their places.
+func (t *Tournament) eliminate(ctx context.Context, stacks map[string]int) error {
+	busted := make([]string, 0)
+	for _, seated := range t.tables {
+		for _, p := range seated.table.Players() {
+			if p.Chips() == 0 {
+				busted = append(busted, p.ID())
+			}
+		}
+	}
+	places := payout.Places(busted, stacks, len(t.seats))
+	for _, id := range busted {
+		seated := t.seats[id]
+		if err := seated.table.Leave(ctx, id); err != nil {
+			return fmt.Errorf("eliminate player (id: %s), err: %w", id, err)
+		}
+		delete(t.seats, id)
+	}
+	// standings are kept from the last place
+	slices.SortStableFunc(busted, func(a, b string) int {
+		return places[b] - places[a]
+	})
+	for _, id := range busted {
+		t.standings = append(t.standings, Standing{Entrant: t.entrant(id), Place: places[id]})
+		if err := t.broadcast(EventEliminate, EventObject{ID: id, Place: places[id]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// balance breaks the tables no longer needed and evens out the others.
+func (t *Tournament) balance(ctx context.Context) error {
+	counts := make([]int, len(t.tables))
+	for i, seated := range t.tables {
+		counts[i] = seated.table.PlayerCount()
+	}
+	broken, moves := balance(counts, t.tableCapacity)
+	for _, m := range moves {
+		if err := t.move(ctx, t.tables[m.from], t.tables[m.to]); err != nil {
+			return err
+		}
+	}
+	if len(broken) == 0 {
+		return nil
+	}
+	for _, i := range broken {
+		if err := t.broadcast(EventBreakTable, EventObject{Table: t.tables[i].number}); err != nil {
+			return err
+		}
+	}
+	for _, i := range broken {
+		if err := t.tables[i].table.Close(ctx); err != nil {
+			return fmt.Errorf("close table %d, err: %w", t.tables[i].number, err)
+		}
+	}
+	t.tables = slices.DeleteFunc(t.tables, func(seated *seatedTable) bool {
+		return seated.table.PlayerCount() == 0
+	})
+	if len(t.tables) == 1 {
+		return t.broadcast(EventFinalTable, EventObject{Table: t.tables[0].number})
+	}
+	return nil
+}
+
+// move moves the player who is due the big blind next with the chips from a table
+// to another, the last seated if no one is.
+func (t *Tournament) move(ctx context.Context, from, to *seatedTable) error {
+	p, ok := from.table.NextBigBlind()
+	if !ok {
+		players := from.table.Players()
+		if len(players) == 0 {
+			return nil
+		}
+		p = players[len(players)-1]
+	}
+	if err := from.table.Leave(ctx, p.ID()); err != nil {
+		return fmt.Errorf("move player (id: %s) from table %d, err: %w", p.ID(), from.number, err)
+	}
+	if err := t.join(ctx, to, p.ID(), p.Chips()); err != nil {
+		return err
+	}
+	return t.broadcast(EventMove, EventObject{ID: p.ID(), Table: to.number})
+}
+
+// pay debits the cost of an entry or a purchase from the player's wallet, if any.
+func (t *Tournament) pay(ctx context.Context, id string, cost int) error {
+	if t.wallet == nil || cost <= 0 {
+		return nil
+	}
+	if err := t.wallet.Debit(ctx, id, cost); err != nil {
+		return fmt.Errorf("debit wallet (id: %s), err: %w", id, err)
+	}
+	return nil
+}
+
+// refund credits the cost of a purchase not made back to the player's wallet, if any.
+func (t *Tournament) refund(ctx context.Context, id string, cost int) error {
+	if t.wallet == nil || cost <= 0 {
+		return nil
+	}
+	if err := t.wallet.Credit(ctx, id, cost); err != nil {
+		return fmt.Errorf("credit wallet (id: %s), err: %w", id, err)
+	}
+	return nil
+}
+
+func (t *Tournament) entrant(id string) Entrant {
+	for _, entrant := range t.entrants {
+		if entrant.ID == id {
+			return entrant
+		}
+	}
+	return Entrant{ID: id}
+}
+
+func (t *Tournament) broadcast(action EventAction, object EventObject) error {
+	if err := t.broadcaster.Action(NewEvent(action, object)); err != nil {
+		return fmt.Errorf("broadcast tournament event, err: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package tournament
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yshngg/holdem/pkg/blind"
+	"github.com/yshngg/holdem/pkg/ledger"
+	"github.com/yshngg/holdem/pkg/payout"
+	"github.com/yshngg/holdem/pkg/player"
+	"github.com/yshngg/holdem/pkg/round"
+	"github.com/yshngg/holdem/pkg/table"
+	"github.com/yshngg/holdem/pkg/watch"
+)
+
+// allIn goes all-in, or calls all-in, whenever it can.
+func allIn(available []player.Action) (player.Action, error) {
+	for _, actionType := range []player.ActionType{player.ActionAllIn, player.ActionCall} {
+		for _, action := range available {
+			if action.Type == actionType {
+				return action, nil
+			}
+		}
+	}
+	return player.CheckOrFold(available)
+}
+
+// seated registers the players and seats them as Start does, the events they watch
+// drained.
+func seated(t *testing.T, tr *Tournament, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		if err := tr.Register(t.Context(), id, id); err != nil {
+			t.Fatalf("register, err: %v", err)
+		}
+	}
+	tr.started = true
+	tr.progress = blind.NewProgress(tr.schedule, time.Now())
+	if err := tr.seat(t.Context()); err != nil {
+		t.Fatalf("seat, err: %v", err)
+	}
+	for _, id := range ids {
+		p, _ := tr.Player(id)
+		go func() {
+			for range p.Watch() {
+			}
+		}()
+	}
+}
+
+// started drains the events of the players seated by Start, then moved, and returns
+// the events of the tournament and the tables first seated. The events are read
+// until the tournament finishes, the tables once it has started.
+func started(t *testing.T, tr *Tournament) (<-chan watch.Event, <-chan []*table.Table) {
+	t.Helper()
+	w, err := tr.Watch()
+	if err != nil {
+		t.Fatalf("watch tournament, err: %v", err)
+	}
+	t.Cleanup(w.Stop)
+	events := make(chan watch.Event, 256)
+	tables := make(chan []*table.Table, 1)
+	drain := func(id string) {
+		if p, ok := tr.Player(id); ok {
+			for range p.Watch() {
+			}
+		}
+	}
+	go func() {
+		for event := range w.Watch() {
+			events <- event
+			object := event.Related().(EventObject)
+			switch EventAction(event.Action()) {
+			case EventStart:
+				// the tournament holds its lock broadcasting, it is taken aside
+				go func() {
+					tr.mu.Lock()
+					seated := make([]*table.Table, 0, len(tr.tables))
+					for _, st := range tr.tables {
+						seated = append(seated, st.table)
+					}
+					tr.mu.Unlock()
+					tables <- seated
+					for _, entrant := range tr.entrants {
+						go drain(entrant.ID)
+					}
+				}()
+			case EventMove:
+				go drain(object.ID)
+			}
+		}
+	}()
+	return events, tables
+}
+
+func TestStart(t *testing.T) {
+	ids := []string{"p0", "p1", "p2", "p3"}
+	wallet := ledger.NewMemoryWallet(map[string]int{"p0": 10, "p1": 10, "p2": 10, "p3": 10})
+	tr := New(
+		WithTableCapacity(2),
+		WithStartingStack(100),
+		WithBuyIn(10),
+		WithWallet(wallet),
+		WithPrizePool(40, payout.Structure{70, 30}),
+		WithTableOptions(table.WithActionClock(round.Clock{Base: time.Millisecond}), table.WithTimeoutPolicy(allIn)),
+	)
+	for _, id := range ids {
+		if err := tr.Register(t.Context(), id, id); err != nil {
+			t.Fatalf("register, err: %v", err)
+		}
+	}
+	events, tables := started(t, tr)
+	if err := tr.Start(t.Context()); err != nil {
+		t.Fatalf("start, err: %v", err)
+	}
+
+	// a player busts at every table heads-up, the last two meet at the final table
+	counts := make(map[EventAction]int)
+	for event := range events {
+		counts[EventAction(event.Action())]++
+		if event.Action() == string(EventFinish) {
+			break
+		}
+	}
+	want := map[EventAction]int{EventEliminate: 3, EventBreakTable: 1, EventFinalTable: 1, EventMove: 1}
+	for action, count := range want {
+		if counts[action] != count {
+			t.Errorf("%s events: %d, want: %d", action, counts[action], count)
+		}
+	}
+	standings := tr.Standings()
+	if len(standings) != len(ids) || standings[0].Place != 1 || standings[0].Prize != 28 || standings[1].Place != 2 || standings[1].Prize != 12 {
+		t.Errorf("standings: %v, want four places, the first two paid", standings)
+	}
+	for _, standing := range standings {
+		if balance, _ := wallet.Balance(t.Context(), standing.ID); balance != standing.Prize {
+			t.Errorf("wallet of %s: %d, want the prize of %d", standing.ID, balance, standing.Prize)
+		}
+	}
+
+	// the table broken up is closed
+	closed := 0
+	for _, tb := range <-tables {
+		if _, err := tb.Watch(); err != nil {
+			closed++
+		}
+	}
+	if closed != 1 {
+		t.Errorf("tables closed: %d, want the table broken up", closed)
+	}
+}
+
+func TestStartNoHandDealt(t *testing.T) {
+	// the small blind times out and sits out after the first hand, the big blind is
+	// left alone
+	tr := New(WithTableOptions(table.WithActionClock(round.Clock{Base: time.Millisecond}), table.WithAutoFoldTimeouts(1)))
+	for _, id := range []string{"p0", "p1"} {
+		if err := tr.Register(t.Context(), id, id); err != nil {
+			t.Fatalf("register, err: %v", err)
+		}
+	}
+	started(t, tr)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- tr.Start(t.Context())
+	}()
+	select {
+	case err := <-done:
+		if reflect.TypeOf(err) != reflect.TypeOf(ErrNoHandDealt{}) {
+			t.Errorf("Start().err = %v, want %T", err, ErrNoHandDealt{})
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("start has not returned, want no hand dealt")
+	}
+}
@@ -0,0 +1,113 @@
+package variant
+
+import (
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/deck"
+	"github.com/yshngg/holdem/pkg/hand"
+)
+
+var (
+	// Holdem is Texas hold'em, two hole cards.
+	Holdem Variant = community{
+		name:    "Hold'em",
+		streets: board(2),
+		limit:   NoLimit,
+		deck:    deck.New,
+		high:    hand.Holdem,
+	}
+
+	// Pineapple deals three hole cards, one of them is discarded before the flop.
+	Pineapple Variant = community{
+		name:    "Pineapple",
+		streets: []Street{{Hole: 3}, {Discard: 1, Board: 3}, {Board: 1}, {Board: 1}},
+		limit:   NoLimit,
+		deck:    deck.New,
+		high:    hand.Holdem,
+	}
+
+	// CrazyPineapple deals three hole cards, one of them is discarded after the flop.
+	CrazyPineapple Variant = community{
+		name:    "Crazy Pineapple",
+		streets: []Street{{Hole: 3}, {Board: 3}, {Discard: 1, Board: 1}, {Board: 1}},
+		limit:   NoLimit,
+		deck:    deck.New,
+		high:    hand.Holdem,
+	}
+
+	// Omaha deals four hole cards, exactly two of them play.
+	Omaha Variant = community{
+		name:    "Omaha",
+		streets: board(4),
+		limit:   PotLimit,
+		deck:    deck.New,
+		high:    hand.Omaha,
+	}
+
+	// OmahaHiLo is Omaha split between the high and the eight-or-better low.
+	OmahaHiLo Variant = community{
+		name:    "Omaha Hi-Lo",
+		streets: board(4),
+		limit:   PotLimit,
+		deck:    deck.New,
+		high:    hand.Omaha,
+		low:     hand.OmahaLow,
+	}
+
+	// ShortDeck is hold'em with the twos to the fives removed.
+	ShortDeck Variant = community{
+		name:    "Short Deck",
+		streets: board(2),
+		limit:   NoLimit,
+		deck:    deck.NewShort,
+		high:    hand.ShortDeck,
+	}
+)
+
+// community is a game of hole cards and a board of community cards: the flop,
+// the turn and the river.
+type community struct {
+	name      string
+	streets   []Street
+	limit     Limit
+	deck      func(...deck.Option) *deck.Deck
+	high, low hand.Evaluator
+}
+
+func (c community) String() string {
+	return c.name
+}
+
+func (c community) Deck() *deck.Deck {
+	return c.deck()
+}
+
+func (c community) Limit() Limit {
+	return c.limit
+}
+
+func (c community) Streets() []Street {
+	return slices.Clone(c.streets)
+}
+
+func (c community) BringIn() bool {
+	return false
+}
+
+func (c community) FirstToAct(street int, up [][]card.Card) int {
+	return -1
+}
+
+func (c community) Evaluator() hand.Evaluator {
+	return c.high
+}
+
+func (c community) Low() hand.Evaluator {
+	return c.low
+}
+
+// board returns the streets of the hole cards, the flop, the turn and the river.
+func board(holeCards int) []Street {
+	return []Street{{Hole: holeCards}, {Board: 3}, {Board: 1}, {Board: 1}}
+}
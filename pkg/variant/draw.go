@@ -0,0 +1,50 @@
+package variant
+
+import (
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/deck"
+	"github.com/yshngg/holdem/pkg/hand"
+)
+
+// TripleDraw27 deals five cards down with three draws, the lowest hand wins, aces
+// are high and straights and flushes count against the hand.
+var TripleDraw27 Variant = draw{}
+
+type draw struct{}
+
+func (draw) String() string {
+	return "2-7 Triple Draw"
+}
+
+func (draw) Deck() *deck.Deck {
+	return deck.New()
+}
+
+func (draw) Limit() Limit {
+	return FixedLimit
+}
+
+func (draw) Streets() []Street {
+	return []Street{
+		{Hole: 5},
+		{Draw: true},
+		{Draw: true, BigBet: true},
+		{Draw: true, BigBet: true},
+	}
+}
+
+func (draw) BringIn() bool {
+	return false
+}
+
+func (draw) FirstToAct(street int, up [][]card.Card) int {
+	return -1
+}
+
+func (draw) Evaluator() hand.Evaluator {
+	return hand.DeuceToSeven
+}
+
+func (draw) Low() hand.Evaluator {
+	return nil
+}
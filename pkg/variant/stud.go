@@ -0,0 +1,124 @@
+package variant
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/deck"
+	"github.com/yshngg/holdem/pkg/hand"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+// SevenCardStud deals two cards down and one up, three more up and the last one
+// down, the best five out of seven play. The lowest up card brings in.
+var SevenCardStud Variant = stud{}
+
+type stud struct{}
+
+func (stud) String() string {
+	return "Seven-Card Stud"
+}
+
+func (stud) Deck() *deck.Deck {
+	return deck.New()
+}
+
+func (stud) Limit() Limit {
+	return FixedLimit
+}
+
+func (stud) Streets() []Street {
+	return []Street{
+		{Hole: 2, Up: 1},        // third street
+		{Up: 1},                 // fourth street
+		{Up: 1, BigBet: true},   // fifth street
+		{Up: 1, BigBet: true},   // sixth street
+		{Hole: 1, BigBet: true}, // seventh street, the river
+	}
+}
+
+func (stud) BringIn() bool {
+	return true
+}
+
+// FirstToAct returns the lowest up card on third street, which brings in, and the
+// highest hand showing on the later streets. Equal hands showing go to the first
+// player from the left of the button.
+func (stud) FirstToAct(street int, up [][]card.Card) int {
+	first := -1
+	for i, cards := range up {
+		if len(cards) == 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+			continue
+		}
+		if street == 0 {
+			if c := cards[len(cards)-1]; compareBringIn(c, up[first][len(up[first])-1]) < 0 {
+				first = i
+			}
+			continue
+		}
+		if compareShowing(cards, up[first]) > 0 {
+			first = i
+		}
+	}
+	return first
+}
+
+func (stud) Evaluator() hand.Evaluator {
+	return hand.Holdem
+}
+
+func (stud) Low() hand.Evaluator {
+	return nil
+}
+
+// bringInSuits breaks the ties between up cards of the same rank for the bring-in,
+// from the lowest suit.
+var bringInSuits = []suit.Suit{suit.Clubs, suit.Diamonds, suit.Hearts, suit.Spades}
+
+func compareBringIn(a, b card.Card) int {
+	if c := cmp.Compare(a.Rank(), b.Rank()); c != 0 {
+		return c
+	}
+	return cmp.Compare(slices.Index(bringInSuits, a.Suit()), slices.Index(bringInSuits, b.Suit()))
+}
+
+// compareShowing compares the hands showing by the cards of the same rank, then by
+// the ranks, straights and flushes do not count.
+func compareShowing(a, b []card.Card) int {
+	countsA, ranksA := showing(a)
+	countsB, ranksB := showing(b)
+	if c := slices.Compare(countsA, countsB); c != 0 {
+		return c
+	}
+	return slices.Compare(ranksA, ranksB)
+}
+
+// showing returns the number of cards of each rank and the ranks, ordered by count
+// and then by rank, from the highest.
+func showing(cards []card.Card) ([]int, []rank.Rank) {
+	counts := make(map[rank.Rank]int)
+	for _, c := range cards {
+		counts[c.Rank()]++
+	}
+	ranks := make([]rank.Rank, 0, len(counts))
+	for r := range counts {
+		ranks = append(ranks, r)
+	}
+	slices.SortFunc(ranks, func(a, b rank.Rank) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(b, a)
+	})
+	result := make([]int, 0, len(ranks))
+	for _, r := range ranks {
+		result = append(result, counts[r])
+	}
+	return result, ranks
+}
@@ -0,0 +1,75 @@
+package variant
+
+import (
+	"testing"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/rank"
+	"github.com/yshngg/holdem/pkg/suit"
+)
+
+func TestStudFirstToAct(t *testing.T) {
+	testCases := []struct {
+		name   string
+		street int
+		up     [][]card.Card
+		want   int
+	}{
+		{
+			name:   "LowestBringsIn",
+			street: 0,
+			up: [][]card.Card{
+				{card.New(rank.King, suit.Clubs)},
+				{card.New(rank.Three, suit.Hearts)},
+				{card.New(rank.Ace, suit.Spades)},
+			},
+			want: 1,
+		},
+		{
+			name:   "BringInSuit",
+			street: 0,
+			up: [][]card.Card{
+				{card.New(rank.Two, suit.Spades)},
+				{card.New(rank.Two, suit.Hearts)},
+				{card.New(rank.Two, suit.Clubs)},
+			},
+			want: 2,
+		},
+		{
+			name:   "HighestShowing",
+			street: 1,
+			up: [][]card.Card{
+				{card.New(rank.King, suit.Clubs), card.New(rank.Queen, suit.Clubs)},
+				{card.New(rank.Ace, suit.Hearts), card.New(rank.Two, suit.Clubs)},
+				{card.New(rank.Ace, suit.Spades), card.New(rank.Three, suit.Diamonds)},
+			},
+			want: 2,
+		},
+		{
+			name:   "PairShowing",
+			street: 2,
+			up: [][]card.Card{
+				{card.New(rank.Ace, suit.Clubs), card.New(rank.King, suit.Clubs), card.New(rank.Queen, suit.Clubs)},
+				{card.New(rank.Four, suit.Hearts), card.New(rank.Four, suit.Clubs), card.New(rank.Two, suit.Hearts)},
+			},
+			want: 1,
+		},
+		{
+			name:   "TieGoesLeft",
+			street: 1,
+			up: [][]card.Card{
+				{card.New(rank.Nine, suit.Clubs), card.New(rank.Eight, suit.Clubs)},
+				{card.New(rank.Nine, suit.Hearts), card.New(rank.Eight, suit.Hearts)},
+			},
+			want: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SevenCardStud.FirstToAct(tc.street, tc.up); got != tc.want {
+				t.Errorf("FirstToAct(%d, %v) = %d, want %d", tc.street, tc.up, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package variant
+
+import (
+	"fmt"
+
+	"github.com/yshngg/holdem/pkg/card"
+	"github.com/yshngg/holdem/pkg/deck"
+	"github.com/yshngg/holdem/pkg/hand"
+)
+
+// Variant is the poker game dealt in a round: what is dealt before every betting
+// round, who acts first, and how the hands are evaluated.
+type Variant interface {
+	fmt.Stringer
+
+	// Deck returns a new deck the variant is dealt from.
+	Deck() *deck.Deck
+
+	// Limit returns the betting structure the variant is usually played with.
+	Limit() Limit
+
+	// Streets returns what is dealt before every betting round, in order.
+	Streets() []Street
+
+	// BringIn reports whether the first player to act on the first street posts a
+	// bring-in, instead of the blinds being posted.
+	BringIn() bool
+
+	// FirstToAct returns the index of the player to act first on the street, given
+	// the up cards of the players in the hand from the left of the button. A
+	// negative index follows the blinds and the button.
+	FirstToAct(street int, up [][]card.Card) int
+
+	// Evaluator returns how the best hand is made out of the hole cards and the board.
+	Evaluator() hand.Evaluator
+
+	// Low returns how the low hand is made in split-pot games, nil in the other games.
+	Low() hand.Evaluator
+}
+
+// Street is what is dealt before a betting round.
+type Street struct {
+	// Hole and Up are the cards dealt to every player, face down and face up.
+	Hole, Up int
+
+	// Board is the number of community cards dealt.
+	Board int
+
+	// Discard is the number of hole cards every player discards before the street
+	// is dealt, as in pineapple.
+	Discard int
+
+	// Draw lets every player discard hole cards and draw as many replacements.
+	Draw bool
+
+	// BigBet doubles the bet size in fixed limit.
+	BigBet bool
+}
+
+// Limit is the betting structure, how much a player can bet or raise.
+type Limit int
+
+const (
+	NoLimit    Limit = iota // up to all the chips
+	PotLimit                // up to the size of the pot after calling
+	FixedLimit              // the small bet, or the big bet on the later streets
+)
+
+func (l Limit) String() string {
+	switch l {
+	case NoLimit:
+		return "No Limit"
+	case PotLimit:
+		return "Pot Limit"
+	case FixedLimit:
+		return "Fixed Limit"
+	default:
+		return "Invalid"
+	}
+}